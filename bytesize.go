@@ -0,0 +1,133 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//ByteSize is a count of bytes, as "512MiB", "1.5G", "64k".
+//It is a Value of size flags.
+type ByteSize uint64
+
+//units of ByteSize, larger first
+var byteUnits = []struct {
+	name string
+	size uint64
+}{
+	{"EiB", 1 << 60}, {"EB", 1e18},
+	{"PiB", 1 << 50}, {"PB", 1e15},
+	{"TiB", 1 << 40}, {"TB", 1e12},
+	{"GiB", 1 << 30}, {"GB", 1e9},
+	{"MiB", 1 << 20}, {"MB", 1e6},
+	{"KiB", 1 << 10}, {"kB", 1e3},
+	{"B", 1},
+}
+
+//byteUnit returns the size of unit suffix, as SI "k", "kB", "M", "MB" ...,
+//or IEC "Ki", "KiB", "Mi", "MiB" ..., in any case
+func byteUnit(suffix string) (uint64, bool) {
+	s := strings.ToLower(suffix)
+	if s == "" || s == "b" {
+		return 1, true
+	}
+	s = strings.TrimSuffix(s, "b")
+	for _, u := range byteUnits {
+		name := strings.ToLower(strings.TrimSuffix(u.name, "B"))
+		if name != "" && s == name {
+			return u.size, true
+		}
+	}
+	return 0, false
+}
+
+var errFractionalBytes = errors.New("fractional bytes")
+
+//ParseByteSize parses s as a number followed by an optional unit.
+//SI units as "k", "MB" are powers of 1000, IEC units as "Ki", "MiB" are powers of 1024.
+//The number may have a fraction if the result is a whole count of bytes.
+func ParseByteSize(s string) (ByteSize, error) {
+	num := strings.TrimSpace(s)
+	i := 0
+	for i < len(num) && (num[i] >= '0' && num[i] <= '9' || num[i] == '.') {
+		i++
+	}
+	num, suffix := num[:i], strings.TrimSpace(num[i:])
+	unit, ok := byteUnit(suffix)
+	if !ok || num == "" || num == "." || strings.Count(num, ".") > 1 {
+		return 0, &strconv.NumError{Func: "ParseByteSize", Num: s, Err: strconv.ErrSyntax}
+	}
+	r, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return 0, &strconv.NumError{Func: "ParseByteSize", Num: s, Err: strconv.ErrSyntax}
+	}
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).SetUint64(unit)))
+	if !r.IsInt() {
+		return 0, &strconv.NumError{Func: "ParseByteSize", Num: s, Err: errFractionalBytes}
+	}
+	n := r.Num()
+	if n.BitLen() > 64 {
+		return math.MaxUint64, &strconv.NumError{Func: "ParseByteSize", Num: s, Err: strconv.ErrRange}
+	}
+	return ByteSize(n.Uint64()), nil
+}
+
+//String returns the text of b in a unit that shows it exactly with at most 3 decimals
+//and the fewest digits, as "1.5KiB" but not "1536B", which ParseByteSize parses back to b
+func (b ByteSize) String() string {
+	v := new(big.Int).SetUint64(uint64(b))
+	best := strconv.FormatUint(uint64(b), 10)
+	bestUnit := "B"
+	for _, u := range byteUnits {
+		if uint64(b) < u.size || u.size == 1 {
+			continue
+		}
+		r := new(big.Rat).SetFrac(v, new(big.Int).SetUint64(u.size))
+		if new(big.Rat).Mul(r, big.NewRat(1000, 1)).IsInt() {
+			s := strings.TrimRight(strings.TrimRight(r.FloatString(3), "0"), ".")
+			d, bestD := numDigits(s), numDigits(best)
+			if d < bestD || d == bestD && len(s+u.name) < len(best+bestUnit) {
+				best, bestUnit = s, u.name
+			}
+		}
+	}
+	return best + bestUnit
+}
+
+//numDigits returns how many digits are in decimal text s
+func numDigits(s string) int {
+	return len(s) - strings.Count(s, ".")
+}
+
+//Set parses s by ParseByteSize
+func (b *ByteSize) Set(s string) error {
+	v, err := ParseByteSize(s)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+//Get returns b as ByteSize
+func (b *ByteSize) Get() interface{} { return *b }
+
+//ByteSizeVar defines a size flag with specified name, default value, and usage string.
+//The argument p points to a ByteSize variable in which to store the value of the flag.
+func (f *FlagSet) ByteSizeVar(p *ByteSize, name string, logic_name string, value ByteSize, required bool, usage string) {
+	*p = value
+	f.Var(p, name, logic_name, required, usage)
+}
+
+//ByteSizeVar defines a size command line flag with specified name, default value, and usage string.
+//The argument p points to a ByteSize variable in which to store the value of the flag.
+func ByteSizeVar(p *ByteSize, name string, logic_name string, value ByteSize, required bool, usage string) {
+	CommandLine.ByteSizeVar(p, name, logic_name, value, required, usage)
+}
@@ -0,0 +1,79 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestParseByteSize(t *testing.T) {
+	for _, c := range []struct {
+		s    string
+		size cmdline.ByteSize
+		str  string
+	}{
+		{"0", 0, "0B"},
+		{"100", 100, "100B"},
+		{"64k", 64000, "64kB"},
+		{"64KiB", 65536, "64KiB"},
+		{"1.5G", 1500000000, "1.5GB"},
+		{"512MiB", 512 << 20, "512MiB"},
+		{"1.5 ki", 1536, "1.5KiB"},
+		{"1234", 1234, "1234B"},
+		{"1.5MiB", 3 << 19, "1.5MiB"},
+		{"0.5gib", 512 << 20, "512MiB"},
+		{"1000kB", 1000000, "1MB"},
+		{"16EiB", 0, ""},
+		{"18446744073709551615", 1<<64 - 1, "18446744073709551615B"},
+		{"1.5B", 0, ""},
+		{"1.2.3k", 0, ""},
+		{"-1k", 0, ""},
+		{"12xB", 0, ""},
+	} {
+		size, err := cmdline.ParseByteSize(c.s)
+		if c.str == "" {
+			if err == nil {
+				t.Errorf("ParseByteSize(%q) = %v, need error", c.s, size)
+			}
+			continue
+		}
+		if err != nil || size != c.size {
+			t.Errorf("ParseByteSize(%q) = %d, %v, need %d", c.s, size, err, c.size)
+			continue
+		}
+		if s := size.String(); s != c.str {
+			t.Errorf("ByteSize(%d).String() = %s, need %s", size, s, c.str)
+		}
+		if back, err := cmdline.ParseByteSize(size.String()); err != nil || back != size {
+			t.Errorf("ParseByteSize(%s) = %d, %v, need %d", size, back, err, size)
+		}
+	}
+	if _, err := cmdline.ParseByteSize("16EiB"); err.(*strconv.NumError).Err != strconv.ErrRange {
+		t.Errorf("ParseByteSize(16EiB) error = %v, need out of range", err)
+	}
+}
+
+func TestByteSizeVar(t *testing.T) {
+	cmd := cmdline.NewFlagSet("size", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.UsageWidth(80)
+	var buf cmdline.ByteSize
+	cmd.ByteSizeVar(&buf, "b", "buffer", 64<<10, false, "buffer size")
+	if err := cmd.Parse([]string{"-b=1.5M"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf != 1500000 || cmd.GetString("b") != "1.5MB" {
+		t.Errorf("buffer = %d(%s)", buf, cmd.GetString("b"))
+	}
+	if need := "  -b=<buffer>  size  (default 64KiB)\n"; !strings.Contains(cmd.GetUsage(), need) {
+		t.Errorf("usage need %q, got:\n%s", need, cmd.GetUsage())
+	}
+}
@@ -18,6 +18,31 @@
        8.  Fix "-flag = x" or "-flag= x" or "-flag =x" cause panic bug
        9.  Add synonyms support for with-name flags
        10. Format usage page lines head with proper num of space
+       11. Wrap usage page to terminal width and align flag columns
+       12. Add flag groups shown as sections of usage page
+       13. Add declaration order visiting, sort no-name flags by sequence number
+       14. Add hidden and deprecated flags
+       15. Add man page generation
+       16. Add Markdown and HTML reference doc generation
+       17. Add JSON serializable schema of command line interface
+       18. Add colorized usage and error messages
+       19. Add localizable usage and error messages
+       20. Add response files (@file) expansion
+       21. Add interactive Shell mode with history and completion
+       22. Fix SplitLine quoting (quotes are removed now), add SplitArgs, SplitWindowsArgs and JoinLine
+       23. Add FlagSet.Reset to reuse a FlagSet for another Parse
+       24. Add Concurrent mode, typed getters and Watch hooks
+       25. Add OnSet callbacks and Validate hooks with common validators
+       26. Add range-bounded numeric flags with RangeError
+       27. Add ByteSize flags with SI and IEC units
+       28. Add time flags accepting layouts, epoch and relative times
+       29. Add IP, CIDR, host:port and URL flags
+       30. Add file, directory and opened file flags
+       31. Add regexp and glob pattern flags
+       32. Add secret flags masked in usage, errors and schema
+       33. Add prompting for missing required flags and enum flags
+       34. Add Dump of effective values and their origins
+       35. Add Source and SetCount of flags, and RejectDuplicates
 
    Usage as follow:
 
@@ -48,9 +73,9 @@
            cmdline.IntVar(&ttl, "t", "ttl", ttl, false, "ttl")
            cmdline.IntVar(&ttl, "ttl", "synonym of -t", ttl, true, "this is synonym of -t")
 
-           //define a synonym with method AnotherName
+           //define a synonym with method Alias
            c := cmdline.Int("c", "count", 0, false, "count")
-           cmdline.AnotherName("count", "c")
+           cmdline.Alias("count", "c")
 
            cmdline.Parse()
 
@@ -69,13 +94,13 @@
            //    ping [-4=<v4>] [-c|count=<count>] [-t|ttl=<ttl>] <host> [<host2>
            //  -4=<v4>
            //      ipv4
-           //  -c|count=<count>  int
+           //  -c|count=<count>            int
            //      count
-           //  -t|ttl=<ttl>  int
+           //  -t|ttl=<ttl>                int
            //      ttl
-           //  <host>  required  string
+           //  <host>            required  string
            //      host ip or name
-           //  <host2>  string
+           //  <host2>                     string
            //      second host ip or name
            //
            //  CopyRight:
@@ -6,12 +6,21 @@
 package cmdline_test
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/vipally/cmdline"
 )
 
+func TestMain(m *testing.M) {
+	os.Setenv("LC_ALL", "C") //expected usage pages are in English
+	os.Exit(m.Run())
+}
+
 func TestGetUsage(t *testing.T) {
 	var sCheck = `Usage of ([<thiscmd>] Build [<versiontime>]):
   Summary:
@@ -21,13 +30,13 @@ func TestGetUsage(t *testing.T) {
     <thiscmd> [-4=<v4>] [-c|count=<count>] [-t|ttl=<ttl>] <host> [<host2>]
   -4=<v4>
     ipv4
-  -c|count=<count>  int
+  -c|count=<count>            int
     count
-  -t|ttl=<ttl>  int
+  -t|ttl=<ttl>                int
     ttl
-  <host>  required  string
+  <host>            required  string
     host ip or name
-  <host2>  string
+  <host2>                     string
     second host ip or name
 
   CopyRight:
@@ -62,6 +71,7 @@ VersionTag  : <versiontag>
 Validity    : <validity>
 <thiscmd> is an example usage of github.com/vipally/cmdline package.`)
 	cmdline.CopyRight("no copyright defined")
+	cmdline.UsageWidth(80)
 
 	//no-name flag and required ones
 	cmdline.StringVar(&host, "", "host", "", true, "host ip or name")
@@ -73,7 +83,7 @@ Validity    : <validity>
 	cmdline.IntVar(&ttl, "t", "ttl", ttl, false, "ttl")
 	cmdline.IntVar(&ttl, "ttl", "synonym of -t", ttl, true, "this is synonym of -t")
 
-	//define a synonym with method AnotherName
+	//define a synonym with method Alias
 	cmdline.IntVar(&c, "c", "count", 0, false, "count")
 	cmdline.Alias("count", "c")
 
@@ -85,6 +95,196 @@ Validity    : <validity>
 	}
 }
 
+func TestUsageWrap(t *testing.T) {
+	var sCheck = `  Usage:
+    <thiscmd> [-l|level=<level>]
+<indent>[-o|output=<file>]
+<indent><src>
+  -l|level=<level>            int     (default 3)
+    compression level, from 1
+    (fastest) to 9 (smallest)
+  -o|output=<file>            string
+    write the result to file
+  <src>             required  string
+    source file
+`
+	sCheck = cmdline.ReplaceTags(sCheck)
+	indent := strings.Repeat(" ", len(cmdline.ReplaceTags("    <thiscmd> ")))
+	sCheck = strings.Replace(sCheck, "<indent>", indent, -1)
+	cmd := cmdline.NewFlagSet("wrap", cmdline.ContinueOnError)
+	cmd.UsageWidth(30)
+	cmd.Int("l", "level", 3, false, "compression level, from 1 (fastest) to 9 (smallest)")
+	cmd.Alias("level", "l")
+	cmd.String("o", "file", "", false, "write the result to file")
+	cmd.Alias("output", "o")
+	cmd.String("", "src", "", true, "source file")
+
+	usage := cmd.GetUsage()
+	if !strings.Contains(usage, sCheck) {
+		t.Errorf("GetUsage fail \nneed:\n%s\ngot:\n%s", sCheck, usage)
+	}
+}
+
+func TestUsageGroup(t *testing.T) {
+	var sCheck = `  Usage:
+    <thiscmd> [-v=<verbose>] -host=<host> [network options] [output options] <file>
+  -v=<verbose>
+    print more logs
+  <file>        required  string
+    file to send
+
+  Network options:
+  -host=<host>  required  string
+    remote host
+  -port=<port>            int     (default 80)
+    remote port
+
+  Output options:
+  -o=<format>             string  (default "text")
+    output format
+`
+	sCheck = cmdline.ReplaceTags(sCheck)
+	cmd := cmdline.NewFlagSet("group", cmdline.ContinueOnError)
+	cmd.UsageWidth(100)
+	cmd.Bool("v", "verbose", false, false, "print more logs")
+	cmd.String("o", "format", "text", false, "output format")
+	cmd.String("host", "host", "", true, "remote host")
+	cmd.Int("port", "port", 80, false, "remote port")
+	cmd.String("", "file", "", true, "file to send")
+	cmd.Group("Network", "host", "port")
+	cmd.Group("Output", "o")
+
+	usage := cmd.GetUsage()
+	if !strings.Contains(usage, sCheck) {
+		t.Errorf("GetUsage fail \nneed:\n%s\ngot:\n%s", sCheck, usage)
+	}
+}
+
+func TestDeclarationOrder(t *testing.T) {
+	cmd := cmdline.NewFlagSet("order", cmdline.ContinueOnError)
+	cmd.Int("z", "z", 0, false, "z")
+	cmd.Int("a", "a", 0, false, "a")
+	var check []string
+	for i := 1; i <= 12; i++ {
+		name := fmt.Sprintf("arg%d", i)
+		cmd.String("", name, "", false, name)
+		check = append(check, name)
+	}
+
+	visit := func() (r []string) {
+		cmd.VisitAll(func(flag *cmdline.Flag) {
+			r = append(r, flag.LogicName)
+		})
+		return
+	}
+	if got, need := visit(), append([]string{"a", "z"}, check...); !reflect.DeepEqual(got, need) {
+		t.Errorf("lexicographical order fail\nneed:%v\ngot :%v", need, got)
+	}
+	cmd.DeclarationOrder(true)
+	if got, need := visit(), append([]string{"z", "a"}, check...); !reflect.DeepEqual(got, need) {
+		t.Errorf("declaration order fail\nneed:%v\ngot :%v", need, got)
+	}
+}
+
+func TestHiddenDeprecated(t *testing.T) {
+	var sCheck = `  Usage:
+    <thiscmd> [-c|count=<count>] [-v=<verbose>]
+  -c|count=<count>  int
+    count
+    -count is deprecated: use -c
+  -v=<verbose>
+    verbose
+`
+	sCheck = cmdline.ReplaceTags(sCheck)
+	var out bytes.Buffer
+	cmd := cmdline.NewFlagSet("hidden", cmdline.ContinueOnError)
+	cmd.SetOutput(&out)
+	cmd.UsageWidth(80)
+	c := cmd.Int("c", "count", 0, false, "count")
+	cmd.Alias("count", "c")
+	cmd.Alias("n", "c")
+	cmd.Bool("v", "verbose", false, false, "verbose")
+	cmd.Bool("debug", "debug", false, false, "debug")
+	cmd.Hidden("n", "debug")
+	cmd.Deprecated("count", "use -c")
+
+	if usage := cmd.GetUsage(); !strings.Contains(usage, sCheck) {
+		t.Errorf("GetUsage fail \nneed:\n%s\ngot:\n%s", sCheck, usage)
+	}
+	if err := cmd.Parse([]string{"-count=1", "-n=2", "-count=3", "-debug"}); err != nil {
+		t.Fatal(err)
+	}
+	if *c != 3 {
+		t.Errorf("count need 3, got %d", *c)
+	}
+	if need := "flag -count is deprecated: use -c\n"; out.String() != need {
+		t.Errorf("deprecation warning need %q, got %q", need, out.String())
+	}
+}
+
+func TestReset(t *testing.T) {
+	cmd := cmdline.NewFlagSet("reset", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	n := cmd.Int("n", "count", 4, false, "count")
+	v := cmd.Bool("v", "verbose", false, false, "verbose")
+	var ip, ip2 string
+	cmd.StringVar(&ip, "", "ip", "", false, "ip")
+	cmd.StringVar(&ip2, "", "ip2", "none", false, "ip2")
+
+	if err := cmd.Parse([]string{"-n=2", "-v", "a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	cmd.Reset()
+	if *n != 4 || *v || ip != "" || ip2 != "none" {
+		t.Errorf("after Reset n=%d v=%v ip=%q ip2=%q", *n, *v, ip, ip2)
+	}
+	if cmd.Parsed() || cmd.NFlag() != 0 || cmd.NArg() != 0 {
+		t.Errorf("after Reset Parsed=%v NFlag=%d NArg=%d", cmd.Parsed(), cmd.NFlag(), cmd.NArg())
+	}
+	if err := cmd.Parse([]string{"c"}); err != nil {
+		t.Fatal(err)
+	}
+	if *n != 4 || *v || ip != "c" || ip2 != "none" {
+		t.Errorf("after reparse n=%d v=%v ip=%q ip2=%q", *n, *v, ip, ip2)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	var sCheck = `  用法:
+    <thiscmd> [-c|count=<count>] <host>
+  -c|count=<count>        int     (默认 4)
+    发送次数
+    -count 已废弃: use -c
+  <host>            必需  string
+    主机
+`
+	sCheck = cmdline.ReplaceTags(sCheck)
+	var out bytes.Buffer
+	cmd := cmdline.NewFlagSet("catalog", cmdline.ContinueOnError)
+	cmd.SetOutput(&out)
+	cmd.UsageWidth(80)
+	cmd.Int("c", "count", 4, false, "发送次数")
+	cmd.Alias("count", "c")
+	cmd.Deprecated("count", "use -c")
+	cmd.String("", "host", "", true, "主机")
+
+	t.Setenv("LC_ALL", "zh_CN.UTF-8")
+	usage := cmd.GetUsage()
+	if !strings.Contains(usage, sCheck) {
+		t.Errorf("GetUsage fail \nneed:\n%s\ngot:\n%s", sCheck, usage)
+	}
+
+	cmd.SetCatalog(cmdline.ChineseCatalog)
+	cmd.DisableUsage(true)
+	if err := cmd.Parse([]string{"-c=x"}); err == nil || err.Error() != `值 "x" 对参数 -c 无效: strconv.ParseInt: parsing "x": invalid syntax` {
+		t.Errorf("localized error fail: %v", err)
+	}
+	cmd.SetCatalog(cmdline.EnglishCatalog)
+	if err := cmd.Parse(nil); err == nil || err.Error() != "require but missing flag <host>" {
+		t.Errorf("english error fail: %v", err)
+	}
+}
+
 func TestNonameFlag(t *testing.T) {
 
 	var (
@@ -0,0 +1,121 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"os"
+)
+
+//ColorMode decides whether usage and error messages are colorized
+type ColorMode int
+
+const (
+	ColorAuto   ColorMode = iota //colorize if Output() is a terminal, honoring NO_COLOR and FORCE_COLOR
+	ColorAlways                  //always colorize
+	ColorNever                   //never colorize
+)
+
+//Theme is the ANSI SGR parameters(eg: "1;31") of each part of usage and error
+//messages. Empty one means no color.
+type Theme struct {
+	Header   string //section headers of usage page
+	Flag     string //flag names
+	Required string //required markers
+	Type     string //value types
+	Default  string //default values
+	Error    string //error messages
+}
+
+//DefaultTheme is the theme used if no one assigned by SetTheme
+var DefaultTheme = Theme{
+	Header:   "1",
+	Flag:     "36",
+	Required: "31",
+	Type:     "33",
+	Default:  "2",
+	Error:    "1;31",
+}
+
+//paint colorizes s with SGR parameters code, nil theme means no color
+func (th *Theme) paint(code, s string) string {
+	if th == nil || code == "" || s == "" {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+//header returns color of section headers
+func (th *Theme) header() string {
+	if th == nil {
+		return ""
+	}
+	return th.Header
+}
+
+//column returns color of flag column i in usage page
+func (th *Theme) column(i int) string {
+	if th == nil {
+		return ""
+	}
+	return [...]string{th.Flag, th.Required, th.Type, th.Default}[i]
+}
+
+//error returns color of error messages
+func (th *Theme) error() string {
+	if th == nil {
+		return ""
+	}
+	return th.Error
+}
+
+//Color set when to colorize usage and error messages, returns the old one
+func Color(mode ColorMode) (old ColorMode) {
+	return CommandLine.Color(mode)
+}
+
+//Color set when to colorize usage and error messages, returns the old one
+func (f *FlagSet) Color(mode ColorMode) (old ColorMode) {
+	old, f.colorMode = f.colorMode, mode
+	return
+}
+
+//SetTheme set the colors of usage and error messages, returns the old one.
+//nil means DefaultTheme.
+func SetTheme(theme *Theme) (old *Theme) {
+	return CommandLine.SetTheme(theme)
+}
+
+//SetTheme set the colors of usage and error messages, returns the old one.
+//nil means DefaultTheme.
+func (f *FlagSet) SetTheme(theme *Theme) (old *Theme) {
+	old, f.theme = f.theme, theme
+	return
+}
+
+//colorTheme returns the theme to colorize messages written to Output(), nil if not colorize
+func (f *FlagSet) colorTheme() *Theme {
+	switch f.colorMode {
+	case ColorNever:
+		return nil
+	case ColorAuto:
+		if os.Getenv("NO_COLOR") != "" {
+			return nil
+		}
+		if force := os.Getenv("FORCE_COLOR"); force == "" || force == "0" {
+			out, ok := f.Output().(*os.File)
+			if !ok || !isTerminal(out.Fd()) {
+				return nil
+			}
+		}
+	}
+	if out, ok := f.Output().(*os.File); ok && isTerminal(out.Fd()) && !enableColor(out.Fd()) {
+		return nil //legacy console prints escape sequences as they are
+	}
+	if f.theme == nil {
+		return &DefaultTheme
+	}
+	return f.theme
+}
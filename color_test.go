@@ -0,0 +1,61 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+var expAnsi = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestColor(t *testing.T) {
+	var out bytes.Buffer
+	cmd := cmdline.NewFlagSet("color", cmdline.ContinueOnError)
+	cmd.SetOutput(&out)
+	cmd.UsageWidth(80)
+	cmd.Int("t", "ttl", 64, true, "time to live")
+	plain := cmd.GetUsage()
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	cmd.PrintDefaults()
+	if out.String() != plain {
+		t.Errorf("auto color to non-terminal fail \nneed:\n%q\ngot:\n%q", plain, out.String())
+	}
+
+	out.Reset()
+	t.Setenv("FORCE_COLOR", "1")
+	cmd.PrintDefaults()
+	colored := out.String()
+	for _, need := range []string{"  \x1b[1mUsage:\x1b[0m\n", "  \x1b[36m-t=<ttl>\x1b[0m  \x1b[31mrequired\x1b[0m"} {
+		if !strings.Contains(colored, need) {
+			t.Errorf("FORCE_COLOR fail \nneed:\n%q\ngot:\n%q", need, colored)
+		}
+	}
+	if s := expAnsi.ReplaceAllString(colored, ""); s != plain {
+		t.Errorf("colored usage differs from plain one \nneed:\n%q\ngot:\n%q", plain, s)
+	}
+
+	out.Reset()
+	t.Setenv("NO_COLOR", "1")
+	cmd.PrintDefaults()
+	if out.String() != plain {
+		t.Errorf("NO_COLOR fail \nneed:\n%q\ngot:\n%q", plain, out.String())
+	}
+
+	out.Reset()
+	cmd.Color(cmdline.ColorAlways)
+	cmd.SetTheme(&cmdline.Theme{Error: "35"})
+	cmd.Parse([]string{"-x"})
+	if need := "\x1b[35mflag provided but not defined: -x\x1b[0m\n"; !strings.HasPrefix(out.String(), need) {
+		t.Errorf("colored error fail \nneed:\n%q\ngot:\n%q", need, out.String())
+	}
+}
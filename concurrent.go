@@ -0,0 +1,270 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"fmt"
+	"time"
+)
+
+//Concurrent makes command line flags safe to Set, get and visit concurrently.
+//It returns the old setting.
+func Concurrent(enable bool) (old bool) {
+	return CommandLine.Concurrent(enable)
+}
+
+//Concurrent makes the FlagSet safe to Set, get and visit concurrently,
+//it must be called before any goroutine uses the FlagSet. It returns the old setting.
+//In concurrent mode, values of flags should be read by GetString, GetInt and so on.
+//Visit and VisitAll copy flags under a read lock and pass the copies to fn out
+//of the lock, so fn can get and Set flags, but not read their Value directly.
+func (f *FlagSet) Concurrent(enable bool) (old bool) {
+	old, f.concurrent = f.concurrent, enable
+	return
+}
+
+//visit calls fn for every flag of formal, or of actual if onlySet
+func (f *FlagSet) visit(onlySet bool, fn func(*Flag)) {
+	if f.concurrent {
+		f.mu.RLock()
+	}
+	flags := f.formal
+	if onlySet {
+		flags = f.actual
+	}
+	list, names := f.orderFlags(flags)
+	if f.concurrent {
+		for i, flag := range list {
+			copied := *flag
+			list[i] = &copied
+		}
+		f.mu.RUnlock()
+	}
+	for i, flag := range list {
+		flag.Visitor = names[i]
+		fn(flag)
+	}
+}
+
+//setValue sets value of flag by name from src, checks it by validators and OnSet
+//callbacks, and notify watchers of the flag. All flag changes of Set and Parse go through it.
+func (f *FlagSet) setValue(flag *Flag, name, value string, src Source) error {
+	input, src, err := readSecret(flag, value, src)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	old := flag.Value.String()
+	restore, release := func() { flag.Value.Set(old) }, func() {}
+	k, isKeeper := flag.Value.(keeper)
+	if isKeeper {
+		restore, release = k.keep()
+	}
+	if err = flag.Value.Set(input); err != nil {
+		if isKeeper {
+			restore()
+		}
+		f.mu.Unlock()
+		return maskError(flag, err)
+	}
+	now := flag.Value.String()
+	f.mu.Unlock()
+
+	err = flag.runHooks(old, now) //out of the lock, so that hooks can get and Set flags
+
+	f.mu.Lock()
+	if err != nil {
+		if flag.Value.String() == now { //roll back the rejected value, unless it is changed by others
+			restore()
+		} else {
+			release()
+		}
+	} else {
+		release()
+		if f.actual == nil {
+			f.actual = make(map[string]*Flag)
+		}
+		f.actual[name] = flag
+		src.Name = name
+		flag.source = src
+		flag.count++
+	}
+	watchers := f.watchers[flag]
+	f.mu.Unlock()
+
+	if err != nil {
+		return maskError(flag, err)
+	}
+	for _, fn := range watchers {
+		fn(flag.Name, now)
+	}
+	return nil
+}
+
+//keeper is a Value that can not roll back by Set its old value, as a truncated file.
+//keep makes the next Set keep the current value, then restore puts it back after
+//a rejected Set, or release drops it after an accepted one.
+type keeper interface {
+	keep() (restore, release func())
+}
+
+//Watch registers fn to be called after command line flag name is set
+func Watch(name string, fn func(name, value string)) {
+	CommandLine.Watch(name, fn)
+}
+
+//Watch registers fn to be called after flag name or any of its synonyms is set
+//by Set or Parse. fn gets the primary name and the new value of the flag.
+//It is called out of any lock, so it can get or Set flags.
+func (f *FlagSet) Watch(name string, fn func(name, value string)) {
+	flag := f.mustLookup("Watch", name)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.watchers == nil {
+		f.watchers = make(map[*Flag][]func(name, value string))
+	}
+	f.watchers[flag] = append(f.watchers[flag], fn)
+}
+
+//get returns the value of flag name under read lock, by Getter if implemented
+func (f *FlagSet) get(method, name string) interface{} {
+	flag := f.mustLookup(method, name)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if g, ok := flag.Value.(Getter); ok {
+		return g.Get()
+	}
+	return flag.Value.String()
+}
+
+//typeMismatch panics for getting a flag by the wrong type
+func (f *FlagSet) typeMismatch(method, name string, value interface{}) {
+	msg := fmt.Sprintf("%s: flag %s is %T", method, name, value)
+	if f.name != "" {
+		msg = f.name + " " + msg
+	}
+	fmt.Fprintln(f.Output(), msg)
+	panic(msg)
+}
+
+//GetString returns the value of command line flag name as string
+func GetString(name string) string {
+	return CommandLine.GetString(name)
+}
+
+//GetString returns the value of flag name as string, for any type of flag
+func (f *FlagSet) GetString(name string) string {
+	flag := f.mustLookup("GetString", name)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return flag.Value.String()
+}
+
+//GetBool returns the value of command line bool flag name
+func GetBool(name string) bool {
+	return CommandLine.GetBool(name)
+}
+
+//GetBool returns the value of bool flag name
+func (f *FlagSet) GetBool(name string) bool {
+	value := f.get("GetBool", name)
+	v, ok := value.(bool)
+	if !ok {
+		f.typeMismatch("GetBool", name, value)
+	}
+	return v
+}
+
+//GetInt returns the value of command line int flag name
+func GetInt(name string) int {
+	return CommandLine.GetInt(name)
+}
+
+//GetInt returns the value of int flag name
+func (f *FlagSet) GetInt(name string) int {
+	value := f.get("GetInt", name)
+	v, ok := value.(int)
+	if !ok {
+		f.typeMismatch("GetInt", name, value)
+	}
+	return v
+}
+
+//GetInt64 returns the value of command line int64 flag name
+func GetInt64(name string) int64 {
+	return CommandLine.GetInt64(name)
+}
+
+//GetInt64 returns the value of int64 flag name
+func (f *FlagSet) GetInt64(name string) int64 {
+	value := f.get("GetInt64", name)
+	v, ok := value.(int64)
+	if !ok {
+		f.typeMismatch("GetInt64", name, value)
+	}
+	return v
+}
+
+//GetUint returns the value of command line uint flag name
+func GetUint(name string) uint {
+	return CommandLine.GetUint(name)
+}
+
+//GetUint returns the value of uint flag name
+func (f *FlagSet) GetUint(name string) uint {
+	value := f.get("GetUint", name)
+	v, ok := value.(uint)
+	if !ok {
+		f.typeMismatch("GetUint", name, value)
+	}
+	return v
+}
+
+//GetUint64 returns the value of command line uint64 flag name
+func GetUint64(name string) uint64 {
+	return CommandLine.GetUint64(name)
+}
+
+//GetUint64 returns the value of uint64 flag name
+func (f *FlagSet) GetUint64(name string) uint64 {
+	value := f.get("GetUint64", name)
+	v, ok := value.(uint64)
+	if !ok {
+		f.typeMismatch("GetUint64", name, value)
+	}
+	return v
+}
+
+//GetFloat64 returns the value of command line float64 flag name
+func GetFloat64(name string) float64 {
+	return CommandLine.GetFloat64(name)
+}
+
+//GetFloat64 returns the value of float64 flag name
+func (f *FlagSet) GetFloat64(name string) float64 {
+	value := f.get("GetFloat64", name)
+	v, ok := value.(float64)
+	if !ok {
+		f.typeMismatch("GetFloat64", name, value)
+	}
+	return v
+}
+
+//GetDuration returns the value of command line time.Duration flag name
+func GetDuration(name string) time.Duration {
+	return CommandLine.GetDuration(name)
+}
+
+//GetDuration returns the value of time.Duration flag name
+func (f *FlagSet) GetDuration(name string) time.Duration {
+	value := f.get("GetDuration", name)
+	v, ok := value.(time.Duration)
+	if !ok {
+		f.typeMismatch("GetDuration", name, value)
+	}
+	return v
+}
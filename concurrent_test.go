@@ -0,0 +1,137 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestConcurrent(t *testing.T) {
+	cmd := cmdline.NewFlagSet("concurrent", cmdline.ContinueOnError)
+	cmd.Concurrent(true)
+	cmd.Int("n", "count", 0, false, "count")
+	cmd.Alias("count", "n")
+	cmd.Duration("t", "timeout", time.Second, false, "timeout")
+
+	var mu sync.Mutex
+	var changes []string
+	cmd.Watch("count", func(name, value string) {
+		mu.Lock()
+		changes = append(changes, name+"="+value)
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if err := cmd.Set("count", strconv.Itoa(i*100+j)); err != nil {
+					t.Error(err)
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = cmd.GetInt("n")
+				_ = cmd.GetString("count")
+				_, _ = cmd.Lookup("n").Source(), cmd.Lookup("n").SetCount()
+				cmd.VisitAll(func(flag *cmdline.Flag) { _ = cmd.GetString(flag.Name) })
+				cmd.Visit(func(flag *cmdline.Flag) {})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(changes) != 400 {
+		t.Errorf("Watch got %d changes, want 400", len(changes))
+	}
+	if err := cmd.Parse([]string{"-n=7", "-t=3s"}); err != nil {
+		t.Fatal(err)
+	}
+	if n, d := cmd.GetInt("count"), cmd.GetDuration("t"); n != 7 || d != 3*time.Second {
+		t.Errorf("GetInt=%d GetDuration=%v", n, d)
+	}
+	if last := changes[len(changes)-1]; last != "n=7" {
+		t.Errorf("last change = %q, want n=7", last)
+	}
+}
+
+func TestConcurrentVisitGet(t *testing.T) {
+	cmd := cmdline.NewFlagSet("concurrent", cmdline.ContinueOnError)
+	cmd.Concurrent(true)
+	cmd.Int("n", "count", 0, false, "count")
+
+	done := make(chan bool)
+	go func() {
+		cmd.VisitAll(func(flag *cmdline.Flag) {
+			set := make(chan bool)
+			go func() {
+				cmd.Set("n", "1") //a writer waits while fn runs
+				close(set)
+			}()
+			time.Sleep(10 * time.Millisecond)
+			_ = cmd.GetInt("n")
+			<-set
+		})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetInt in VisitAll deadlocks with a waiting Set")
+	}
+}
+
+func TestConcurrentUsage(t *testing.T) {
+	cmd := cmdline.NewFlagSet("concurrent", cmdline.ContinueOnError)
+	cmd.Concurrent(true)
+	var n int
+	cmd.IntRangeVar(&n, "n", "count", 5, 1, 1000, false, "count")
+	cmd.String("s", "name", "def", false, "name")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for j := 1; j <= 100; j++ {
+			cmd.Set("n", strconv.Itoa(j))
+			cmd.Set("s", strconv.Itoa(j))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 20; j++ {
+			if usage := cmd.GetUsage(); !strings.Contains(usage, `"def"`) {
+				t.Errorf("usage lost the default:\n%s", usage)
+			}
+			_ = cmd.GetManPage(1)
+			_ = cmd.GetMarkdown()
+		}
+	}()
+	wg.Wait()
+}
+
+func TestGetTypeMismatch(t *testing.T) {
+	cmd := cmdline.NewFlagSet("mismatch", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.String("s", "s", "x", false, "string")
+	defer func() {
+		if r := recover(); r != "mismatch GetInt: flag s is string" {
+			t.Errorf("recover() = %v", r)
+		}
+	}()
+	cmd.GetInt("s")
+}
@@ -0,0 +1,157 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//Origin is where the value of a flag comes from
+type Origin int
+
+const (
+	OriginDefault     Origin = iota //default value
+	OriginCommandLine               //command line arguments
+	OriginEnv                       //environment variable of a secret flag
+	OriginFile                      //response file, or file of a secret flag
+	OriginPrompt                    //prompted input
+	OriginSet                       //FlagSet.Set
+)
+
+var originNames = [...]string{"default", "command line", "env", "file", "prompt", "set"}
+
+func (o Origin) String() string {
+	if o < 0 || int(o) >= len(originNames) {
+		return fmt.Sprintf("Origin(%d)", int(o))
+	}
+	return originNames[o]
+}
+
+//MarshalText encodes o as its name
+func (o Origin) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+//Origin returns where the value of flag comes from
+func (f *Flag) Origin() Origin {
+	return f.Source().Origin
+}
+
+//DumpFormat is the format of Dump
+type DumpFormat int
+
+const (
+	DumpText   DumpFormat = iota //aligned lines of name=value and origin
+	DumpJSON                     //JSON array of FlagDump
+	DumpConfig                   //response file that ResponseFiles can read back
+)
+
+//FlagDump is the effective value of a flag
+type FlagDump struct {
+	Name      string `json:"name"` //show name as "-c|count=", empty for no-name flags
+	LogicName string `json:"logicName"`
+	Value     string `json:"value"` //masked for secret flags
+	Origin    Origin `json:"origin"`
+	Secret    bool   `json:"secret,omitempty"`
+}
+
+//Dump writes effective values of command line flags to w in format
+func Dump(w io.Writer, format DumpFormat) error {
+	return CommandLine.Dump(w, format)
+}
+
+//Dump writes the effective value and origin of every flag to w in format,
+//values of secret flags are masked. In DumpConfig format, flags of default
+//values, empty values, secret ones and values of line breaks are written as comments,
+//so that the file is read back to the same configuration.
+func (f *FlagSet) Dump(w io.Writer, format DumpFormat) error {
+	var flags []FlagDump
+	var bools []bool
+	f.VisitAll(func(flag *Flag) {
+		if flag.Visitor != flag.Name { //Synonyms show at the first one only
+			return
+		}
+		flags = append(flags, FlagDump{
+			Name:      flag.GetShowName(),
+			LogicName: flag.LogicName,
+			Value:     flag.mask(f.GetString(flag.Name)),
+			Origin:    flag.source.Origin,
+			Secret:    flag.Secret,
+		})
+		fv, ok := flag.Value.(boolFlag)
+		bools = append(bools, ok && fv.IsBoolFlag())
+	})
+
+	var buf bytes.Buffer
+	switch format {
+	case DumpText:
+		width := 0
+		for _, d := range flags {
+			if w := textWidth(d.dumpName() + d.Value); w > width {
+				width = w
+			}
+		}
+		for _, d := range flags {
+			s := d.dumpName() + d.Value
+			fmt.Fprintf(&buf, "%s%s  %s\n", s, strings.Repeat(" ", width-textWidth(s)), d.Origin)
+		}
+	case DumpJSON:
+		b, err := json.MarshalIndent(flags, "", "  ")
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		buf.WriteString("\n")
+	case DumpConfig:
+		fmt.Fprintf(&buf, "# effective configuration of %s\n", f.name)
+		for i, d := range flags {
+			buf.WriteString(d.configLine(bools[i]))
+			buf.WriteString("\n")
+		}
+	default:
+		return fmt.Errorf("unknown dump format %d", int(format))
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+//dumpName returns show name and logic name of flag, as "-c|count=<count>="
+func (d *FlagDump) dumpName() string {
+	if d.Name == "" {
+		return "<" + d.LogicName + ">="
+	}
+	return d.Name
+}
+
+//configLine returns the response file line of the flag. The value is quoted by JoinLine,
+//as SplitArgs of readResponseFile splits it back, and quoted for detectString before,
+//if Parse may take it as a flag, a response file or a quoted one.
+func (d *FlagDump) configLine(isBool bool) string {
+	name := strings.TrimSuffix(strings.SplitN(d.Name, "|", 2)[0], "=") //the first synonym
+	value := d.Value
+	if value != "" && (isFlagLeadByte(value[0]) || strings.IndexByte(`=@"'`, value[0]) >= 0) {
+		value = `"` + value + `"`
+	}
+	value = JoinLine([]string{value})
+
+	line := ""
+	switch {
+	case d.Name == "":
+		line = value
+	case isBool:
+		line = JoinLine([]string{name + "=" + d.Value})
+	default:
+		line = name + " " + value
+	}
+	if d.Origin == OriginDefault || d.Value == "" || d.Secret || strings.ContainsAny(d.Value, "\r\n") {
+		line = "# " + line
+	}
+	return line
+}
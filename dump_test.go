@@ -0,0 +1,149 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestDump(t *testing.T) {
+	dir := t.TempDir()
+	args := filepath.Join(dir, "args.txt")
+	if err := ioutil.WriteFile(args, []byte("-t=32\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := cmdline.NewFlagSet("dump", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.ResponseFiles(true)
+	host := cmd.String("", "host", "", false, "host")
+	count := cmd.Int("c", "count", 4, false, "count")
+	cmd.Alias("count", "c")
+	ttl := cmd.Int("t", "ttl", 64, false, "ttl")
+	name := cmd.String("n", "name", "", false, "name")
+	verbose := cmd.Bool("v", "verbose", false, false, "verbose")
+	cmd.String("p", "password", "", false, "password")
+	cmd.Secret("p")
+	if err := cmd.Parse([]string{"-count=8", "@" + args, "-v", "-n", `-a "b"`, "-p=pw", `"my host"`}); err != nil {
+		t.Fatal(err)
+	}
+	cmd.Set("t", "16")
+
+	var text bytes.Buffer
+	if err := cmd.Dump(&text, cmdline.DumpText); err != nil {
+		t.Fatal(err)
+	}
+	need := `-c|count=8      command line
+-n=-a "b"       command line
+-p=******       command line
+-t=16           set
+-v=true         command line
+<host>=my host  command line
+`
+	if text.String() != need {
+		t.Errorf("text dump\nneed:\n%s\ngot:\n%s", need, text.String())
+	}
+
+	var js bytes.Buffer
+	if err := cmd.Dump(&js, cmdline.DumpJSON); err != nil {
+		t.Fatal(err)
+	}
+	var dumps []map[string]interface{}
+	if err := json.Unmarshal(js.Bytes(), &dumps); err != nil {
+		t.Fatal(err)
+	}
+	if len(dumps) != 6 || dumps[3]["origin"] != "set" || dumps[2]["value"] != "******" {
+		t.Errorf("json dump = %s", js.String())
+	}
+
+	var config bytes.Buffer
+	if err := cmd.Dump(&config, cmdline.DumpConfig); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(config.String(), "# effective configuration of dump\n") {
+		t.Errorf("config dump header:\n%s", config.String())
+	}
+	file := filepath.Join(dir, "config.txt")
+	if err := ioutil.WriteFile(file, config.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd2 := cmdline.NewFlagSet("dump", cmdline.ContinueOnError)
+	cmd2.SetOutput(new(bytes.Buffer))
+	cmd2.ResponseFiles(true)
+	host2 := cmd2.String("", "host", "", false, "host")
+	count2 := cmd2.Int("c", "count", 4, false, "count")
+	cmd2.Alias("count", "c")
+	ttl2 := cmd2.Int("t", "ttl", 64, false, "ttl")
+	name2 := cmd2.String("n", "name", "", false, "name")
+	verbose2 := cmd2.Bool("v", "verbose", false, false, "verbose")
+	password2 := cmd2.String("p", "password", "", false, "password")
+	cmd2.Secret("p")
+	if err := cmd2.Parse([]string{"@" + file}); err != nil {
+		t.Fatal(err)
+	}
+	const format = "host=%q count=%d ttl=%d name=%q verbose=%v password=%q"
+	got := fmt.Sprintf(format, *host2, *count2, *ttl2, *name2, *verbose2, *password2)
+	if need := fmt.Sprintf(format, *host, *count, *ttl, *name, *verbose, ""); got != need {
+		t.Errorf("config dump round trip %q, need %q\nconfig:\n%s", got, need, config.String())
+	}
+	if o := cmd2.Lookup("t").Origin(); o != cmdline.OriginFile {
+		t.Errorf("origin of -t = %v, need file", o)
+	}
+	if !strings.Contains(config.String(), "# -p '******'\n") {
+		t.Errorf("secret not commented in config:\n%s", config.String())
+	}
+}
+
+func TestDumpConfigRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	for i, value := range []string{
+		"@secret.txt", "-x", `"quoted"`, "'single'", "=eq", `a\b`, `it's "both"`,
+		"#hash", " spaced ", "tab\there", "$HOME", "多字节",
+	} {
+		var name, host string
+		cmd := cmdline.NewFlagSet("dump", cmdline.ContinueOnError)
+		cmd.SetOutput(new(bytes.Buffer))
+		cmd.ResponseFiles(true)
+		cmd.StringVar(&name, "n", "name", "", false, "name")
+		cmd.StringVar(&host, "", "host", "", false, "host")
+		if err := cmd.Parse([]string{`"` + value + `"`}); err != nil {
+			t.Fatalf("Parse host %q: %v", value, err)
+		}
+		if err := cmd.Set("n", value); err != nil {
+			t.Fatal(err)
+		}
+
+		var config bytes.Buffer
+		if err := cmd.Dump(&config, cmdline.DumpConfig); err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(dir, fmt.Sprintf("config%d.txt", i))
+		if err := ioutil.WriteFile(file, config.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		var name2, host2 string
+		cmd2 := cmdline.NewFlagSet("dump", cmdline.ContinueOnError)
+		cmd2.SetOutput(new(bytes.Buffer))
+		cmd2.ResponseFiles(true)
+		cmd2.StringVar(&name2, "n", "name", "", false, "name")
+		cmd2.StringVar(&host2, "", "host", "", false, "host")
+		if err := cmd2.Parse([]string{"@" + file}); err != nil {
+			t.Errorf("Parse config of %q: %v\nconfig:\n%s", value, err, config.String())
+			continue
+		}
+		if name2 != value || host2 != value {
+			t.Errorf("round trip of %q: name=%q host=%q\nconfig:\n%s", value, name2, host2, config.String())
+		}
+	}
+}
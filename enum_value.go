@@ -0,0 +1,70 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Chooser is a Value that accepts a fixed set of choices only.
+//They show as the type in usage page, and as a menu when prompting.
+type Chooser interface {
+	Value
+	Choices() []string
+}
+
+// -- enum Value
+type enumValue struct {
+	p       *string
+	choices []string
+}
+
+func newEnumValue(val string, p *string, choices []string) *enumValue {
+	*p = val
+	return &enumValue{p: p, choices: choices}
+}
+
+func (e *enumValue) Set(s string) error {
+	for _, c := range e.choices {
+		if s == c {
+			*e.p = s
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(e.choices, "|"))
+}
+
+func (e *enumValue) Get() interface{} { return *e.p }
+
+//reset sets def without checking, as the default value may not be one of choices
+func (e *enumValue) reset(def string) {
+	*e.p = def
+}
+
+func (e *enumValue) target() interface{} { return e.p }
+
+func (e *enumValue) String() string {
+	if e == nil || e.p == nil {
+		return ""
+	}
+	return *e.p
+}
+
+func (e *enumValue) Choices() []string { return e.choices }
+
+//EnumVar defines a string flag that accepts one of choices only,
+//with specified name, default value, and usage string.
+//The argument p points to a string variable in which to store the value of the flag.
+func (f *FlagSet) EnumVar(p *string, name string, logic_name string, value string, choices []string, required bool, usage string) {
+	f.Var(newEnumValue(value, p, choices), name, logic_name, required, usage)
+}
+
+//EnumVar defines a string command line flag that accepts one of choices only,
+//with specified name, default value, and usage string.
+func EnumVar(p *string, name string, logic_name string, value string, choices []string, required bool, usage string) {
+	CommandLine.Var(newEnumValue(value, p, choices), name, logic_name, required, usage)
+}
@@ -35,9 +35,9 @@ func main() {
 	cmdline.IntVar(&ttl, "t", "ttl", ttl, false, "ttl")
 	cmdline.IntVar(&ttl, "ttl", "synonym of -t", ttl, true, "this is synonym of -t")
 
-	//define a synonym with method AnotherName
+	//define a synonym with method Alias
 	c := cmdline.Int("c", "count", 0, false, "count")
-	cmdline.AnotherName("count", "c")
+	cmdline.Alias("count", "c")
 
 	cmdline.Parse()
 
@@ -56,13 +56,13 @@ func main() {
 	//    ping [-4=<v4>] [-c|count=<count>] [-t|ttl=<ttl>] <host> [<host2>
 	//  -4=<v4>
 	//      ipv4
-	//  -c|count=<count>  int
+	//  -c|count=<count>            int
 	//      count
-	//  -t|ttl=<ttl>  int
+	//  -t|ttl=<ttl>                int
 	//      ttl
-	//  <host>  required  string
+	//  <host>            required  string
 	//      host ip or name
-	//  <host2>  string
+	//  <host2>                     string
 	//      second host
 	//      ip or name
 	//
@@ -4,7 +4,11 @@
 
 package cmdline
 
-import "os"
+import (
+	"bufio"
+	"io"
+	"os"
+)
 
 // Additional routines compiled into the package only during testing.
 
@@ -18,3 +22,8 @@ func ResetForTesting(usage func()) {
 	CommandLine.Usage = commandLineUsage
 	Usage = usage
 }
+
+// EditLine exports Shell.editLine for testing.
+func (s *Shell) EditLine(in *bufio.Reader, out io.Writer) (string, error) {
+	return s.editLine(in, out)
+}
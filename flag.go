@@ -75,6 +75,8 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -296,6 +298,24 @@ type FlagSet struct {
 	versionTag   string //version tag
 	validity     string //validity period
 	disableUsage bool
+	usageWidth   int              //width of usage page, 0 means auto detect
+	groups       []string         //flag groups in declared order
+	declared     []string         //flag names in declaration order
+	declOrder    bool             //visit flags in declaration order
+	warned       map[string]bool  //deprecated names that have been warned
+	colorMode    ColorMode        //when to colorize usage and error messages
+	theme        *Theme           //colors of usage and error messages, nil means DefaultTheme
+	catalog      Catalog          //texts of usage and error messages, nil means choose by environment
+	respFiles    bool             //expand "@file" arguments
+	prompt       bool             //prompt for missing required flags
+	promptIn     io.Reader        //where prompted values are read from, nil means os.Stdin
+	noDups       bool             //reject flags set more than once by arguments
+	argSources   []Source         //sources of arguments
+	argSet       map[*Flag]Source //flags set by arguments of the current Parse, and where they are
+
+	mu         sync.RWMutex                         //guards values of flags and actual
+	concurrent bool                                 //Visit and VisitAll pass copies of flags under read lock
+	watchers   map[*Flag][]func(name, value string) //called after a flag is set
 }
 
 // A Flag represents the state of a flag.
@@ -309,17 +329,36 @@ type Flag struct {
 	Required  bool     //if this flag is force required
 	Synonyms  []string //different flags(eg:-f/-flag) maybe the same ones, they are synonyms
 	Visitor   string   //name of what synonym is visiting this flag
+	Group     string   //name of the usage section this flag belongs to, empty for the main one
+	Secret    bool     //value is masked in usage page, errors and dumps
+
+	hidden     map[string]bool   //synonyms that are accepted but not shown
+	deprecated map[string]string //deprecated synonyms and their notes
+
+	validators []Validator                   //check new values before they are accepted
+	onSet      []func(old, new string) error //called when value changes, error rejects the change
+	source     Source                        //where and how the value is set at the last time
+	count      int                           //times the value is set since the last Reset
+	mu         *sync.RWMutex                 //lock of the FlagSet, guards source and count
 }
 
 // sortFlags returns the flags as a slice in lexicographical sorted order.
+// No-name flags are sorted by their sequence number, after the named ones.
 func sortFlags(flags map[string]*Flag) ([]*Flag, []string) {
-	list := make(sort.StringSlice, len(flags))
+	list := make([]string, len(flags))
 	i := 0
 	for name, _ := range flags {
 		list[i] = name
 		i++
 	}
-	list.Sort()
+	sort.Slice(list, func(i, j int) bool {
+		idi, okI := nonameId(list[i])
+		idj, okJ := nonameId(list[j])
+		if okI && okJ {
+			return idi < idj
+		}
+		return list[i] < list[j]
+	})
 	result := make([]*Flag, len(list))
 	for i, name := range list {
 		result[i] = flags[name]
@@ -352,14 +391,28 @@ func (f *FlagSet) SetOutput(output io.Writer) {
 	f.output = output
 }
 
-// VisitAll visits the flags in lexicographical order, calling fn for each.
+// orderFlags returns the flags as a slice in declaration order if
+// DeclarationOrder is enabled, or in lexicographical order otherwise.
+func (f *FlagSet) orderFlags(flags map[string]*Flag) ([]*Flag, []string) {
+	if !f.declOrder {
+		return sortFlags(flags)
+	}
+	var result []*Flag
+	var list []string
+	for _, name := range f.declared {
+		if flag, ok := flags[name]; ok {
+			result = append(result, flag)
+			list = append(list, name)
+		}
+	}
+	return result, list
+}
+
+// VisitAll visits the flags in lexicographical order, or declaration order
+// if DeclarationOrder is enabled, calling fn for each.
 // It visits all flags, even those not set.
 func (f *FlagSet) VisitAll(fn func(*Flag)) {
-	list, names := sortFlags(f.formal)
-	for i, flag := range list {
-		flag.Visitor = names[i]
-		fn(flag)
-	}
+	f.visit(false, fn)
 }
 
 // VisitAll visits the command-line flags in lexicographical order, calling
@@ -368,14 +421,11 @@ func VisitAll(fn func(*Flag)) {
 	CommandLine.VisitAll(fn)
 }
 
-// Visit visits the flags in lexicographical order, calling fn for each.
+// Visit visits the flags in lexicographical order, or declaration order
+// if DeclarationOrder is enabled, calling fn for each.
 // It visits only those flags that have been set.
 func (f *FlagSet) Visit(fn func(*Flag)) {
-	list, names := sortFlags(f.actual)
-	for i, flag := range list {
-		flag.Visitor = names[i]
-		fn(flag)
-	}
+	f.visit(true, fn)
 }
 
 // Visit visits the command-line flags in lexicographical order, calling fn
@@ -399,16 +449,14 @@ func Lookup(name string) *Flag {
 func (f *FlagSet) Set(name, value string) error {
 	flag, ok := f.formal[name]
 	if !ok {
-		return fmt.Errorf("no such flag -%v", name)
+		return fmt.Errorf(f.msg(MsgNoSuchFlag), name)
 	}
-	err := flag.Value.Set(value)
-	if err != nil {
+	if err := f.setValue(flag, name, value, Source{Origin: OriginSet}); err != nil {
+		if e, ok := err.(*hookError); ok {
+			return fmt.Errorf(f.msg(MsgRejectedValue), flag.mask(value), flag.GetShowName(), flag.LogicName, e.err)
+		}
 		return err
 	}
-	if f.actual == nil {
-		f.actual = make(map[string]*Flag)
-	}
-	f.actual[name] = flag
 	return nil
 }
 
@@ -423,7 +471,11 @@ func isZeroValue(flag *Flag, value string) bool {
 	// Build a zero value of the flag's Value type, and see if the
 	// result of calling its String method equals the value passed in.
 	// This works unless the Value type is itself an interface type.
-	typ := reflect.TypeOf(flag.Value)
+	v := flag.Value
+	if r, ok := v.(*rangeValue); ok { // zero value of range is the one of its bounded Value
+		v = r.Value
+	}
+	typ := reflect.TypeOf(v)
 	var z reflect.Value
 	if typ.Kind() == reflect.Ptr {
 		z = reflect.New(typ.Elem())
@@ -454,8 +506,16 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 		}
 	}
 	// No explicit name, so use type if we can find one.
+	name = valueTypeName(flag.Value)
+	return
+}
+
+//valueTypeName returns the name of the type of value in usage page
+func valueTypeName(value Value) (name string) {
 	name = "value"
-	switch flag.Value.(type) {
+	switch v := value.(type) {
+	case *rangeValue:
+		name = valueTypeName(v.Value)
 	case boolFlag:
 		name = ""
 	case *durationValue:
@@ -468,6 +528,31 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 		name = "string"
 	case *uintValue, *uint64Value:
 		name = "uint"
+	case *ByteSize:
+		name = "size"
+	case *timeValue:
+		name = "time"
+	case *ipValue:
+		name = "ip"
+	case *ipNetValue:
+		name = "cidr"
+	case *hostPortValue:
+		name = "host:port"
+	case *urlValue:
+		name = "url"
+	case *pathValue:
+		name = "file"
+		if v.dir {
+			name = "dir"
+		}
+	case *openFileValue:
+		name = "file"
+	case *regexpValue:
+		name = "regexp"
+	case *globValue:
+		name = "glob"
+	case Chooser:
+		name = strings.Join(v.Choices(), "|")
 	}
 	return
 }
@@ -533,9 +618,9 @@ func PrintDefaults() {
 // defaultUsage is the default function to print a usage message.
 func (f *FlagSet) defaultUsage() {
 	if f.name == "" {
-		fmt.Fprintf(f.Output(), "Usage:\n")
+		fmt.Fprintln(f.Output(), f.msg(MsgUsage))
 	} else {
-		fmt.Fprintf(f.Output(), "Usage of %s:\n", f.name)
+		fmt.Fprintf(f.Output(), f.msg(MsgUsageOfSet)+"\n", f.name)
 	}
 	f.PrintDefaults()
 }
@@ -847,7 +932,8 @@ func Var(value Value, name string, usage string) {
 func (f *FlagSet) failf(format string, a ...interface{}) error {
 	err := fmt.Errorf(format, a...)
 	if !f.disableUsage {
-		fmt.Fprintln(f.Output(), err)
+		th := f.colorTheme()
+		fmt.Fprintln(f.Output(), th.paint(th.error(), err.Error()))
 		f.usage()
 	}
 	return err
@@ -869,6 +955,7 @@ func (f *FlagSet) parseOne() (bool, error) {
 		return false, nil
 	}
 	ss := f.args[0]
+	src := f.argSource()
 	s, isString := detectString(ss) // avoid parse "--help" "show hello" as flags
 	if s == "" || isFlagLead(s) {
 		f.args = f.args[1:]
@@ -896,7 +983,7 @@ func (f *FlagSet) parseOne() (bool, error) {
 	}
 
 	if len(name) == 0 || isFlagLeadByte(name[0]) || name[0] == '=' {
-		return false, f.failf("bad flag syntax: %s", s)
+		return false, f.failf(f.msg(MsgBadSyntax), s)
 	}
 
 	// it's a flag. does it have an argument?
@@ -909,18 +996,21 @@ func (f *FlagSet) parseOne() (bool, error) {
 			f.usage()
 			return false, ErrHelp
 		}
-		return false, f.failf("flag provided but not defined: -%s", name)
+		return false, f.failf(f.msg(MsgNotDefined), name)
+	}
+	if err := f.checkDuplicate(flag, name, src); err != nil {
+		return false, err
 	}
 
 	// how to fix "--boolFlag = false" ?
 	if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() { // special case: doesn't need an arg
 		if value != "" {
-			if err := fv.Set(value); err != nil {
-				return false, f.failf("invalid boolean value %q for -%s: %v", value, name, err)
+			if err := f.setValue(flag, name, value, src); err != nil {
+				return false, f.setFailed(flag, value, err, f.msg(MsgInvalidBoolValue), flag.mask(value), name)
 			}
 		} else {
-			if err := fv.Set("true"); err != nil {
-				return false, f.failf("invalid boolean flag %s: %v", name, err)
+			if err := f.setValue(flag, name, "true", src); err != nil {
+				return false, f.setFailed(flag, "true", err, f.msg(MsgInvalidBoolFlag), name)
 			}
 		}
 	} else {
@@ -936,16 +1026,13 @@ func (f *FlagSet) parseOne() (bool, error) {
 		}
 
 		if value == "" {
-			return false, f.failf("flag needs an argument: -%s", name)
+			return false, f.failf(f.msg(MsgNeedsArgument), name)
 		}
-		if err := flag.Value.Set(value); err != nil {
-			return false, f.failf("invalid value %q for flag -%s: %v", value, name, err)
+		if err := f.setValue(flag, name, value, src); err != nil {
+			return false, f.setFailed(flag, value, err, f.msg(MsgInvalidValue), flag.mask(value), name)
 		}
 	}
-	if f.actual == nil {
-		f.actual = make(map[string]*Flag)
-	}
-	f.actual[name] = flag
+	f.warnDeprecated(flag, name)
 	return true, nil
 }
 
@@ -6,17 +6,21 @@
 package cmdline
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"os"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	gNoNamePrefix = "{noname#"
+	gNoNamePrefix     = "{noname#"
+	defaultUsageWidth = 80 //usage page width if it can't be detected
 )
 
 var expTag = regexp.MustCompile("<([A-Za-z][a-zA-Z0-9_]*)>")
@@ -27,11 +31,36 @@ var expSpace = regexp.MustCompile(`^.*\s.*$`)
 // no-name ones returns empty and others synonyms
 func (f *Flag) GetShowName() (r string) {
 	if !strings.HasPrefix(f.Name, gNoNamePrefix) {
-		r = fmt.Sprintf("-%s=", f.GetSynonyms())
+		synonyms := f.visibleSynonyms()
+		if len(synonyms) == 0 {
+			synonyms = f.Synonyms
+		}
+		r = fmt.Sprintf("-%s=", strings.Join(synonyms, "|"))
 	}
 	return
 }
 
+//IsHidden reports whether all synonyms of this flag are hidden
+func (f *Flag) IsHidden() bool {
+	return len(f.visibleSynonyms()) == 0
+}
+
+//Deprecation returns the deprecation note of synonym name, empty if it is not deprecated
+func (f *Flag) Deprecation(name string) string {
+	return f.deprecated[name]
+}
+
+//visibleSynonyms returns synonyms that are not hidden
+func (f *Flag) visibleSynonyms() []string {
+	var r []string
+	for _, v := range f.Synonyms {
+		if !f.hidden[v] {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
 //GetSynonyms return synonyms of this flag, as "f|flag" format
 func (f *Flag) GetSynonyms() string {
 	var b bytes.Buffer
@@ -47,7 +76,7 @@ func (f *Flag) GetSynonyms() string {
 }
 
 func (f *FlagSet) PrintDefaults() {
-	fmt.Fprint(f.Output(), f.GetUsage())
+	fmt.Fprint(f.Output(), f.getUsage(f.colorTheme()))
 }
 
 // BoolVar defines a bool flag with specified name, default value, and usage string.
@@ -262,8 +291,18 @@ func Duration(name string, logic_name string, value time.Duration, required bool
 	return CommandLine.Duration(name, logic_name, value, required, usage)
 }
 
+//targeter is a Value that wraps the variable it sets, which is returned by target
+type targeter interface {
+	target() interface{}
+}
+
+//getValuePtr returns the address of the variable value sets, to find synonyms
 func getValuePtr(value Value) (r uintptr) {
-	v := reflect.ValueOf(value)
+	var p interface{} = value
+	if t, ok := value.(targeter); ok {
+		p = t.target()
+	}
+	v := reflect.ValueOf(p)
 	if v.Kind() == reflect.Ptr {
 		r = v.Pointer()
 	}
@@ -302,12 +341,22 @@ func (f *FlagSet) Var(value Value, name string, logic_name string, required bool
 		}
 	}
 	if nil == flag {
-		flag = &Flag{name, usage, value, value.String(), logic_name, required, []string{name}, ""}
+		flag = &Flag{
+			Name:      name,
+			Usage:     usage,
+			Value:     value,
+			DefValue:  value.String(),
+			LogicName: logic_name,
+			Required:  required,
+			Synonyms:  []string{name},
+			mu:        &f.mu,
+		}
 	}
 	if f.formal == nil {
 		f.formal = make(map[string]*Flag)
 	}
 	f.formal[name] = flag
+	f.declared = append(f.declared, name)
 }
 
 // Var defines a flag with the specified name and usage string. The type and
@@ -331,77 +380,191 @@ func GetUsage() string {
 
 //GetUsage returns the usage string
 func (f *FlagSet) GetUsage() string {
+	return f.getUsage(nil)
+}
+
+//getUsage returns the usage string colorized by th, nil th means no color
+func (f *FlagSet) getUsage(th *Theme) string {
+	width := f.getUsageWidth()
 	buf := bytes.NewBufferString("")
-	buf.WriteString(fmt.Sprintf("Usage of ([%s] Build [%s]):\n", thisCmd, f.GetVersionTime()))
+	buf.WriteString(th.paint(th.header(), fmt.Sprintf(f.msg(MsgUsageOf), thisCmd, f.GetVersionTime())))
+	buf.WriteString("\n")
 	if f.summary != "" {
-		buf.WriteString(fmt.Sprintf("  Summary:\n%s\n\n", FormatLineHead(f.summary, "    ")))
+		buf.WriteString(fmt.Sprintf("  %s\n%s\n\n", th.paint(th.header(), f.msg(MsgSummary)), FormatLineHead(f.summary, "    ")))
 	}
 
-	buf.WriteString(fmt.Sprintf("  Usage:\n    %s", thisCmd))
-	f.VisitAll(func(flag *Flag) {
-		if flag.Visitor != flag.Name { //Synonyms show at the first one only
-			return
+	flags := f.usageFlags()
+
+	buf.WriteString(fmt.Sprintf("  %s\n", th.paint(th.header(), f.msg(MsgUsage))))
+	buf.WriteString(formatSynopsis(thisCmd, synopsisItems(flags, f.msg(MsgGroupSynopsis)), width))
+	f.writeFlagsUsage(buf, flags, width, th)
+
+	if f.copyright != "" {
+		buf.WriteString(fmt.Sprintf("\n  %s\n%s", th.paint(th.header(), f.msg(MsgCopyRight)), FormatLineHead(f.copyright, "    ")))
+		if f.copyright[len(f.copyright)-1] != '\n' {
+			buf.WriteRune('\n')
 		}
+	}
+
+	if f.details != "" {
+		buf.WriteString(fmt.Sprintf("\n  %s\n%s\n", th.paint(th.header(), f.msg(MsgDetails)), FormatLineHead(f.details, "    ")))
+	}
+
+	return buf.String()
+}
 
+//usageFlag is a flag to show in usage page. It is a copy of the flag with its type name,
+//usage text, default and range taken under read lock, as Set may change them in concurrent mode.
+type usageFlag struct {
+	*Flag
+	typeName string //name of the value type, or the back-quoted name in usage
+	text     string //usage text without back quotes
+	showDef  bool   //if the default value shows
+	min, max string //bounds of range flags
+	ranged   bool   //if the flag is a range one
+}
+
+//usageFlags returns flags to show in usage page, the ungrouped ones come first
+//and then each group in declared order
+func (f *FlagSet) usageFlags() []usageFlag {
+	if f.concurrent {
+		f.mu.RLock()
+	}
+	var flags []usageFlag
+	list, names := f.orderFlags(f.formal)
+	for i, flag := range list {
+		if names[i] != flag.Name || flag.IsHidden() { //Synonyms show at the first one only
+			continue
+		}
+		copied := *flag
+		copied.Visitor = names[i]
+		u := usageFlag{Flag: &copied, showDef: flag.showDefault()}
+		u.typeName, u.text = UnquoteUsage(flag)
+		u.min, u.max, u.ranged = flag.Range()
+		flags = append(flags, u)
+	}
+	if f.concurrent {
+		f.mu.RUnlock()
+	}
+	order := make(map[string]int, len(f.groups))
+	for i, group := range f.groups {
+		order[group] = i + 1
+	}
+	sort.SliceStable(flags, func(i, j int) bool {
+		return order[flags[i].Group] < order[flags[j].Group]
+	})
+	return flags
+}
+
+//synopsisItems returns the flag items of synopsis line.
+//Optional flags of a group show as groupFmt and no-name ones show at tail.
+func synopsisItems(flags []usageFlag, groupFmt string) (items []string) {
+	var nonames []string
+	shownGroups := make(map[string]bool)
+	for _, flag := range flags {
+		if flag.Group != "" && !flag.Required { //optional ones of a group show as a whole
+			if !shownGroups[flag.Group] {
+				shownGroups[flag.Group] = true
+				items = append(items, fmt.Sprintf(groupFmt, strings.ToLower(flag.Group)))
+			}
+			continue
+		}
 		_fmt := ""
 		if flag.Required {
-			_fmt = " %s<%s>"
+			_fmt = "%s<%s>"
 		} else {
-			_fmt = " [%s<%s>]"
+			_fmt = "[%s<%s>]"
 		}
-		s := fmt.Sprintf(_fmt, flag.GetShowName(), flag.LogicName)
-		buf.WriteString(s)
-	})
-	buf.WriteString("\n")
+		item := fmt.Sprintf(_fmt, flag.GetShowName(), flag.LogicName)
+		if strings.HasPrefix(flag.Name, gNoNamePrefix) {
+			nonames = append(nonames, item)
+		} else {
+			items = append(items, item)
+		}
+	}
+	items = append(items, nonames...)
+	return
+}
 
-	f.VisitAll(func(flag *Flag) {
-		if flag.Visitor != flag.Name { //Synonyms show at the first one only
-			return
+//formatSynopsis lays items out after cmd, continued lines are aligned after cmd
+func formatSynopsis(cmd string, items []string, width int) string {
+	var buf bytes.Buffer
+	head := "    " + cmd
+	indent := strings.Repeat(" ", textWidth(head))
+	buf.WriteString(head)
+	cur := textWidth(head)
+	for _, item := range items {
+		w := textWidth(item)
+		if width > 0 && cur+1+w > width && cur > len(indent) {
+			buf.WriteString("\n")
+			buf.WriteString(indent)
+			cur = len(indent)
 		}
+		buf.WriteString(" ")
+		buf.WriteString(item)
+		cur += 1 + w
+	}
+	buf.WriteString("\n")
+	return buf.String()
+}
 
-		s := fmt.Sprintf("  %s<%s>", flag.GetShowName(), flag.LogicName) // Two spaces before -; see next two comments.
-		buf.WriteString(s)
+//writeFlagsUsage writes one entry per flag, with name/required/type/default columns aligned
+func (f *FlagSet) writeFlagsUsage(buf *bytes.Buffer, flags []usageFlag, width int, th *Theme) {
+	const numCols = 4 // name, required, type, default
+	rows := make([][numCols]string, len(flags))
+	usages := make([]string, len(flags))
+	var colWidth [numCols]int
+	for i, flag := range flags {
+		row := &rows[i]
+		row[0] = fmt.Sprintf("%s<%s>", flag.GetShowName(), flag.LogicName)
 		if flag.Required {
-			buf.WriteString("  required")
-		}
-		name, usage := UnquoteUsage(flag)
-		if len(name) > 0 {
-			buf.WriteString("  ")
-			buf.WriteString(name)
+			row[1] = f.msg(MsgRequired)
 		}
-		if !isZeroValue(flag, flag.DefValue) {
+		row[2], usages[i] = flag.typeName, flag.text
+		if flag.showDef {
 			if _, ok := flag.Value.(*stringValue); ok {
 				// put quotes on the value
-				buf.WriteString(fmt.Sprintf(" (default %q)", flag.DefValue))
+				row[3] = fmt.Sprintf(f.msg(MsgDefault), strconv.Quote(flag.DefValue))
 			} else {
-				buf.WriteString(fmt.Sprintf(" (default %v)", flag.DefValue))
+				row[3] = fmt.Sprintf(f.msg(MsgDefault), flag.DefValue)
 			}
 		}
-		// Boolean flags of one ASCII letter are so common we
-		// treat them specially, putting their usage on the same line.
-		if len(s) <= 4 { // space, space, '-', 'x'.
-			buf.WriteString("\t")
-		} else {
-			// Four spaces before the tab triggers good alignment
-			// for both 4- and 8-space tab stops.
-			buf.WriteString("\n")
+		if flag.ranged {
+			row[3] = strings.TrimLeft(row[3]+" "+fmt.Sprintf(f.msg(MsgRange), flag.min, flag.max), " ")
 		}
-		buf.WriteString(FormatLineHead(usage, "    "))
-		buf.WriteString("\n")
-	})
-
-	if f.copyright != "" {
-		buf.WriteString(fmt.Sprintf("\n  CopyRight:\n%s", FormatLineHead(f.copyright, "    ")))
-		if f.copyright[len(f.copyright)-1] != '\n' {
-			buf.WriteRune('\n')
+		for j, col := range row {
+			if w := textWidth(col); w > colWidth[j] {
+				colWidth[j] = w
+			}
 		}
 	}
 
-	if f.details != "" {
-		buf.WriteString(fmt.Sprintf("\n  Details:\n%s\n", FormatLineHead(f.details, "    ")))
+	for i, row := range rows {
+		if group := flags[i].Group; group != "" && (i == 0 || flags[i-1].Group != group) {
+			buf.WriteString(fmt.Sprintf("\n  %s\n", th.paint(th.header(), fmt.Sprintf(f.msg(MsgGroupOptions), group))))
+		}
+		var line bytes.Buffer
+		line.WriteString("  ") // Two spaces before -
+		for j, col := range row {
+			if colWidth[j] == 0 {
+				continue
+			}
+			if j > 0 {
+				line.WriteString("  ")
+			}
+			line.WriteString(th.paint(th.column(j), col))
+			line.WriteString(strings.Repeat(" ", colWidth[j]-textWidth(col)))
+		}
+		buf.WriteString(strings.TrimRight(line.String(), " "))
+		buf.WriteString("\n")
+		buf.WriteString(FormatLineHead(WrapText(usages[i], width-4), "    "))
+		buf.WriteString("\n")
+		for _, name := range flags[i].visibleSynonyms() {
+			if note := flags[i].Deprecation(name); note != "" {
+				buf.WriteString(fmt.Sprintf("    "+f.msg(MsgDeprecatedNote)+"\n", name, note))
+			}
+		}
 	}
-
-	return buf.String()
 }
 
 func (f *FlagSet) handleError(err error) (bool, error) {
@@ -420,8 +583,10 @@ func (f *FlagSet) handleError(err error) (bool, error) {
 
 //check if there is a required flag and do not set it
 func (f *FlagSet) checkRequiredFlag() error {
-	for _, flg := range f.formal {
-		if flg.Required {
+	var in *bufio.Reader
+	flags, names := sortFlags(f.formal)
+	for i, flg := range flags {
+		if flg.Required && names[i] == flg.Name {
 			hasSet := false
 			for _, synon := range flg.Synonyms {
 				if _, ok := f.actual[synon]; ok {
@@ -429,8 +594,14 @@ func (f *FlagSet) checkRequiredFlag() error {
 					break
 				}
 			}
+			if !hasSet && f.canPrompt() {
+				if in == nil {
+					in = f.promptReader()
+				}
+				hasSet = f.promptFlag(flg, in) == nil
+			}
 			if !hasSet {
-				return f.failf("require but missing flag %s<%s>", flg.GetShowName(), flg.LogicName)
+				return f.failf(f.msg(MsgRequireMissing), flg.GetShowName(), flg.LogicName)
 			}
 		}
 	}
@@ -605,6 +776,15 @@ func (f *FlagSet) GetCopyRight() string {
 	return f.copyright
 }
 
+//nonameId returns sequence number of a no-name flag
+func nonameId(name string) (id int, ok bool) {
+	if !strings.HasPrefix(name, gNoNamePrefix) || !strings.HasSuffix(name, "}") {
+		return 0, false
+	}
+	id, err := strconv.Atoi(name[len(gNoNamePrefix) : len(name)-1])
+	return id, err == nil
+}
+
 //auto genterate a name if name not assigned
 func (f *FlagSet) getAutoName(name string) string {
 	if name == "" || strings.HasPrefix(name, gNoNamePrefix) {
@@ -614,6 +794,96 @@ func (f *FlagSet) getAutoName(name string) string {
 	return name
 }
 
+//Group put flags of names into usage section group.
+//Groups are shown in usage page by the order they first declared.
+func Group(group string, names ...string) {
+	CommandLine.Group(group, names...)
+}
+
+//Group put flags of names into usage section group.
+//Groups are shown in usage page by the order they first declared.
+func (f *FlagSet) Group(group string, names ...string) {
+	if group == "" {
+		panic("Group: empty group name")
+	}
+	found := false
+	for _, v := range f.groups {
+		if v == group {
+			found = true
+			break
+		}
+	}
+	if !found {
+		f.groups = append(f.groups, group)
+	}
+	for _, name := range names {
+		f.mustLookup("Group", name).Group = group
+	}
+}
+
+//Hidden makes flags of names still accepted but not shown in usage page.
+//Names are synonyms, a flag is hidden only if all its synonyms are hidden.
+func Hidden(names ...string) {
+	CommandLine.Hidden(names...)
+}
+
+//Hidden makes flags of names still accepted but not shown in usage page.
+//Names are synonyms, a flag is hidden only if all its synonyms are hidden.
+func (f *FlagSet) Hidden(names ...string) {
+	for _, name := range names {
+		flag := f.mustLookup("Hidden", name)
+		if flag.hidden == nil {
+			flag.hidden = make(map[string]bool)
+		}
+		flag.hidden[name] = true
+	}
+}
+
+//Deprecated marks synonym name as deprecated. It is still accepted, but warns
+//with note once when used, and the note is shown in usage page.
+func Deprecated(name, note string) {
+	CommandLine.Deprecated(name, note)
+}
+
+//Deprecated marks synonym name as deprecated. It is still accepted, but warns
+//with note once when used, and the note is shown in usage page.
+func (f *FlagSet) Deprecated(name, note string) {
+	flag := f.mustLookup("Deprecated", name)
+	if flag.deprecated == nil {
+		flag.deprecated = make(map[string]string)
+	}
+	flag.deprecated[name] = note
+}
+
+//warnDeprecated warns once if name is a deprecated synonym of flag
+func (f *FlagSet) warnDeprecated(flag *Flag, name string) {
+	note := flag.Deprecation(name)
+	if note == "" || f.warned[name] {
+		return
+	}
+	if f.warned == nil {
+		f.warned = make(map[string]bool)
+	}
+	f.warned[name] = true
+	fmt.Fprintf(f.Output(), f.msg(MsgDeprecatedWarning)+"\n", name, note)
+}
+
+//mustLookup returns flag of name, panic if not exists
+func (f *FlagSet) mustLookup(method, name string) *Flag {
+	flag, ok := f.formal[name]
+	if !ok {
+		var msg string
+		if f.name == "" {
+			msg = fmt.Sprintf("%s: flag %s not exists", method, name)
+		} else {
+			msg = fmt.Sprintf("%s %s: flag %s not exists", f.name, method, name)
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	return flag
+}
+
 //Alias add a synonym flag newname for old
 func Alias(newname, old string) (ok bool) {
 	return CommandLine.Alias(newname, old)
@@ -635,6 +905,7 @@ func (f *FlagSet) Alias(newname, old string) (ok bool) {
 		if flag, _ok := f.formal[old]; _ok {
 			flag.Synonyms = append(flag.Synonyms, newname)
 			f.formal[newname] = flag
+			f.declared = append(f.declared, newname)
 		} else {
 			msg = fmt.Sprintf("Alias: old name %s not exists", old)
 			ok = false
@@ -660,7 +931,17 @@ func (f *FlagSet) Alias(newname, old string) (ok bool) {
 // The return value will be ErrHelp if -help or -h were set but not defined.
 func (f *FlagSet) Parse(arguments []string) error {
 	f.parsed = true
-	f.args = arguments
+	sources := commandLineSources(arguments)
+	if f.respFiles {
+		expanded, expandedSources, _, err := f.expandResponseFiles(arguments, sources, 0)
+		if err != nil {
+			if ok, err := f.handleError(f.failf(f.msg(MsgResponseFile), err)); ok {
+				return err
+			}
+		}
+		arguments, sources = expanded, expandedSources
+	}
+	f.args, f.argSources, f.argSet = arguments, sources, nil
 	f.autoId = 0 //reset auto_id for parse logic to generate noname flags
 	for {
 		seen, err := f.parseOne()
@@ -682,6 +963,83 @@ func (f *FlagSet) Parse(arguments []string) error {
 	return nil
 }
 
+//Reset restores command line flags to their default values and forgets the last Parse
+func Reset() {
+	CommandLine.Reset()
+}
+
+//Reset restores all flags to their default values and forgets the last Parse,
+//so that the FlagSet can Parse another arguments as a new one.
+//Values are restored by Set(DefValue), or by themselves if Set can not restore the default one,
+//as the empty DefValue of zero time. A Value that can not Set its DefValue keeps its current value.
+func (f *FlagSet) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, flag := range f.formal {
+		if r, ok := flag.Value.(resetter); ok {
+			r.reset(flag.DefValue)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.source, flag.count = Source{}, 0
+	}
+	f.actual = nil
+	f.args = nil
+	f.argSources = nil
+	f.argSet = nil
+	f.parsed = false
+	f.autoId = 0
+	f.warned = nil
+}
+
+//resetter is a Value that restores its default value def by itself for Reset
+type resetter interface {
+	reset(def string)
+}
+
+//UsageWidth set the line width the usage page wraps at, 0 means auto detect.
+//It returns the old one.
+func UsageWidth(width int) (old int) {
+	return CommandLine.UsageWidth(width)
+}
+
+//UsageWidth set the line width the usage page wraps at, 0 means auto detect.
+//It returns the old one.
+func (f *FlagSet) UsageWidth(width int) (old int) {
+	old, f.usageWidth = f.usageWidth, width
+	return
+}
+
+//getUsageWidth returns the width assigned by UsageWidth, or the width of terminal
+//Output() refers to, or $COLUMNS, or defaultUsageWidth by order
+func (f *FlagSet) getUsageWidth() int {
+	if f.usageWidth > 0 {
+		return f.usageWidth
+	}
+	if out, ok := f.Output().(*os.File); ok {
+		if w := terminalWidth(out.Fd()); w > 0 {
+			return w
+		}
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return defaultUsageWidth
+}
+
+//DeclarationOrder makes VisitAll, Visit and usage page follow the order flags
+//are declared instead of lexicographical order. It returns the old setting.
+func DeclarationOrder(enable bool) (old bool) {
+	return CommandLine.DeclarationOrder(enable)
+}
+
+//DeclarationOrder makes VisitAll, Visit and usage page follow the order flags
+//are declared instead of lexicographical order. It returns the old setting.
+func (f *FlagSet) DeclarationOrder(enable bool) (old bool) {
+	old, f.declOrder = f.declOrder, enable
+	return
+}
+
 //disable usage   DisableUsage
 func (f *FlagSet) DisableUsage(enable bool) bool {
 	old := f.disableUsage
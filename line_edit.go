@@ -0,0 +1,130 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+//control keys of line editing
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyBackspace = 8
+	keyTab       = '\t'
+	keyEscape    = 0x1b
+	keyDelete    = 0x7f
+)
+
+//editLine reads a line of a terminal in raw mode from in, and echoes it to out.
+//Tab completes the last word by Complete, up and down arrows walk through
+//the history, Ctrl-C discards the line, and Ctrl-D of an empty line returns io.EOF.
+func (s *Shell) editLine(in *bufio.Reader, out io.Writer) (string, error) {
+	var line []rune
+	hist := len(s.history)
+	fmt.Fprint(out, s.Prompt)
+	for {
+		r, _, err := in.ReadRune()
+		if err != nil {
+			return string(line), err
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(out, "\n")
+			return string(line), nil
+		case keyCtrlC:
+			fmt.Fprint(out, "^C\n"+s.Prompt)
+			line, hist = line[:0], len(s.history)
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprint(out, "\n")
+				return "", io.EOF
+			}
+		case keyBackspace, keyDelete:
+			if n := len(line); n > 0 {
+				fmt.Fprint(out, strings.Repeat("\b \b", textWidth(string(line[n-1]))))
+				line = line[:n-1]
+			}
+		case keyTab:
+			line = s.completeLine(line, out)
+		case keyEscape:
+			up, down := readEscape(in)
+			switch {
+			case up && hist > 0:
+				hist--
+			case down && hist < len(s.history):
+				hist++
+			default:
+				continue
+			}
+			text := ""
+			if hist < len(s.history) {
+				text = s.history[hist]
+			}
+			fmt.Fprint(out, strings.Repeat("\b \b", textWidth(string(line)))+text)
+			line = []rune(text)
+		default:
+			if r >= ' ' {
+				line = append(line, r)
+				fmt.Fprint(out, string(r))
+			}
+		}
+	}
+}
+
+//readEscape reads the rest of an escape sequence, and reports whether it is up or down arrow
+func readEscape(in *bufio.Reader) (up, down bool) {
+	r, _, err := in.ReadRune()
+	if err != nil || r != '[' && r != 'O' {
+		return false, false
+	}
+	for { //parameters end with a final byte in '@'-'~'
+		if r, _, err = in.ReadRune(); err != nil || r >= '@' && r <= '~' {
+			break
+		}
+	}
+	return r == 'A', r == 'B'
+}
+
+//completeLine completes the last word of line by Complete, and echoes the change to out.
+//A unique candidate is completed with a space, unless it is a flag waiting for its value.
+//Candidates are listed if they have no longer common prefix.
+func (s *Shell) completeLine(line []rune, out io.Writer) []rune {
+	text := string(line)
+	word := text[strings.LastIndexAny(text, " \t")+1:]
+	var candidates []string
+	for _, v := range s.Complete(text) {
+		if strings.HasPrefix(v, word) {
+			candidates = append(candidates, v)
+		}
+	}
+	add := ""
+	switch len(candidates) {
+	case 0:
+		fmt.Fprint(out, "\a")
+	case 1:
+		add = candidates[0][len(word):]
+		if !strings.HasSuffix(candidates[0], "=") {
+			add += " "
+		}
+	default:
+		prefix := candidates[0]
+		for _, v := range candidates[1:] {
+			for !strings.HasPrefix(v, prefix) || !utf8.ValidString(prefix) {
+				prefix = prefix[:len(prefix)-1]
+			}
+		}
+		if add = prefix[len(word):]; add == "" {
+			fmt.Fprint(out, "\n"+strings.Join(candidates, "  ")+"\n"+s.Prompt+text)
+		}
+	}
+	fmt.Fprint(out, add)
+	return append(line, []rune(add)...)
+}
@@ -0,0 +1,45 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestEditLine(t *testing.T) {
+	flags := cmdline.NewFlagSet("ping", cmdline.ContinueOnError)
+	flags.SetOutput(new(bytes.Buffer))
+	flags.Int("n", "count", 4, false, "count of requests")
+	flags.Bool("v", "verbose", false, false, "verbose output")
+	sh := cmdline.NewShell("> ")
+	sh.Out = new(bytes.Buffer)
+	sh.Handle("ping", flags, nil)
+	if err := sh.Exec("ping -v"); err != nil {
+		t.Fatal(err)
+	}
+
+	in := bufio.NewReader(strings.NewReader("pi\t-\tn\t3\r" + //completion
+		"\x1b[A\r" + "\x1b[A\x1b[B\x1b[3~abc\x7f\r" + //history and editing
+		"xy\x03z\n" + "\x04"))
+	var out bytes.Buffer
+	for _, want := range []string{"ping -n=3", "ping -v", "ab", "z"} {
+		if got, err := sh.EditLine(in, &out); err != nil || got != want {
+			t.Errorf("EditLine() = %q, %v, want %q", got, err, want)
+		}
+	}
+	if got, err := sh.EditLine(in, &out); err != io.EOF || got != "" {
+		t.Errorf("EditLine() of Ctrl-D = %q, %v, want io.EOF", got, err)
+	}
+	if s := out.String(); !strings.Contains(s, "\n-n=  -v\n> ping -") {
+		t.Errorf("candidates are not listed in %q", s)
+	}
+}
@@ -0,0 +1,156 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+var roffEscaper = strings.NewReplacer(`\`, `\e`, "-", `\-`)
+
+//roffEscape escapes s as roff text, indentation of lines is kept for no-fill blocks
+func roffEscape(s string) string {
+	lines := strings.Split(roffEscaper.Replace(s), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line //avoid being taken as a control line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+//isNoneInfo reports whether info s is not assigned
+func isNoneInfo(s string) bool {
+	return s == "" || s == "<none>"
+}
+
+//GetManPage returns the usage page in roff man page format of section
+func GetManPage(section int) string {
+	return CommandLine.GetManPage(section)
+}
+
+//GetManPage returns the usage page in roff man page format of section.
+//It is generated from the same info of GetUsage, with sections
+//NAME, SYNOPSIS, DESCRIPTION, OPTIONS, COPYRIGHT and VERSION.
+func (f *FlagSet) GetManPage(section int) string {
+	var buf bytes.Buffer
+	date := f.GetVersionTime()
+	if isNoneInfo(date) {
+		date = ""
+	}
+	source := ""
+	if !isNoneInfo(f.GetAppName()) {
+		source = f.GetAppName()
+		if !isNoneInfo(f.GetVersion()) {
+			source += " " + f.GetVersion()
+		}
+	}
+	buf.WriteString(fmt.Sprintf(".TH \"%s\" \"%d\" \"%s\" \"%s\" \"User Commands\"\n",
+		roffEscape(strings.ToUpper(thisCmd)), section, roffEscape(date), roffEscape(source)))
+
+	buf.WriteString(".SH NAME\n")
+	buf.WriteString(roffEscape(thisCmd))
+	if !isNoneInfo(f.summary) {
+		buf.WriteString(` \- `)
+		buf.WriteString(roffEscape(strings.SplitN(strings.TrimSpace(f.summary), "\n", 2)[0]))
+	}
+	buf.WriteString("\n")
+
+	flags := f.usageFlags()
+	buf.WriteString(".SH SYNOPSIS\n")
+	buf.WriteString(fmt.Sprintf(".B %s\n", roffEscape(thisCmd)))
+	for _, flag := range flags {
+		item := manFlagName(flag.Flag)
+		if !flag.Required {
+			item = "[" + item + "]"
+		}
+		buf.WriteString(item)
+		buf.WriteString("\n")
+	}
+
+	if !isNoneInfo(f.summary) || !isNoneInfo(f.details) {
+		buf.WriteString(".SH DESCRIPTION\n")
+		if !isNoneInfo(f.summary) {
+			buf.WriteString(roffEscape(f.summary))
+			buf.WriteString("\n")
+		}
+		if !isNoneInfo(f.details) {
+			buf.WriteString(".PP\n.nf\n")
+			buf.WriteString(roffEscape(f.details))
+			buf.WriteString("\n.fi\n")
+		}
+	}
+
+	if len(flags) > 0 {
+		buf.WriteString(".SH OPTIONS\n")
+	}
+	for i, flag := range flags {
+		if flag.Group != "" && (i == 0 || flags[i-1].Group != flag.Group) {
+			buf.WriteString(fmt.Sprintf(".SS %s options\n", roffEscape(flag.Group)))
+		}
+		buf.WriteString(".TP\n")
+		buf.WriteString(manFlagName(flag.Flag))
+		name, usage := flag.typeName, flag.text
+		if flag.Required {
+			buf.WriteString("  required")
+		}
+		if name != "" {
+			buf.WriteString("  ")
+			buf.WriteString(roffEscape(name))
+		}
+		buf.WriteString("\n")
+		buf.WriteString(roffEscape(usage))
+		buf.WriteString("\n")
+		if flag.showDef {
+			buf.WriteString(fmt.Sprintf(".br\nDefault: %s\n", roffEscape(flag.DefValue)))
+		}
+		for _, synonym := range flag.visibleSynonyms() {
+			if note := flag.Deprecation(synonym); note != "" {
+				buf.WriteString(fmt.Sprintf(".br\n\\fB\\-%s\\fR is deprecated: %s\n", roffEscape(synonym), roffEscape(note)))
+			}
+		}
+	}
+
+	if !isNoneInfo(f.copyright) {
+		buf.WriteString(".SH COPYRIGHT\n")
+		buf.WriteString(roffEscape(strings.TrimRight(f.copyright, "\n")))
+		buf.WriteString("\n")
+	}
+
+	var version []string
+	for _, v := range [][2]string{
+		{"Version", f.GetVersion()},
+		{"VersionTime", f.GetVersionTime()},
+		{"VersionTag", f.GetVersionTag()},
+		{"Validity", f.GetValidity()},
+	} {
+		if !isNoneInfo(v[1]) {
+			version = append(version, fmt.Sprintf("%s: %s", v[0], roffEscape(v[1])))
+		}
+	}
+	if len(version) > 0 {
+		buf.WriteString(".SH VERSION\n")
+		buf.WriteString(strings.Join(version, "\n.br\n"))
+		buf.WriteString("\n")
+	}
+
+	return buf.String()
+}
+
+//manFlagName returns bold synonyms and italic logic name of flag, as "-f|flag=<name>" in usage page
+func manFlagName(flag *Flag) string {
+	logicName := fmt.Sprintf(`<\fI%s\fR>`, roffEscape(flag.LogicName))
+	if strings.HasPrefix(flag.Name, gNoNamePrefix) {
+		return logicName
+	}
+	synonyms := flag.visibleSynonyms()
+	for i, v := range synonyms {
+		synonyms[i] = `\fB` + roffEscape(v) + `\fR`
+	}
+	return `\-` + strings.Join(synonyms, "|") + "=" + logicName
+}
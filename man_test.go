@@ -0,0 +1,43 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestGetManPage(t *testing.T) {
+	cmd := cmdline.NewFlagSet("man", cmdline.ContinueOnError)
+	cmd.AppName("example")
+	cmd.Version("1.0.2")
+	cmd.VersionTime("2018-09-01")
+	cmd.Summary("<thiscmd> is an example of cmdline package usage.")
+	cmd.CopyRight("no copyright defined")
+	cmd.Details("usage:\n    example -t=32 host\n.hidden\n'quoted")
+	cmd.Int("t", "ttl", 64, false, "time to live")
+	cmd.Alias("ttl", "t")
+	cmd.String("", "host", "", true, "host ip or name")
+
+	thisCmd := cmdline.ReplaceTags("<thiscmd>")
+	page := cmd.GetManPage(1)
+	for _, need := range []string{
+		`.TH "` + strings.ToUpper(thisCmd) + `" "1" "2018\-09\-01" "example 1.0.2" "User Commands"`,
+		".SH NAME\n" + thisCmd + ` \- ` + thisCmd + " is an example of cmdline package usage.\n",
+		".SH SYNOPSIS\n.B " + thisCmd + "\n" + `[\-\fBt\fR|\fBttl\fR=<\fIttl\fR>]` + "\n" + `<\fIhost\fR>` + "\n",
+		".TP\n" + `\-\fBt\fR|\fBttl\fR=<\fIttl\fR>  int` + "\ntime to live\n.br\nDefault: 64\n",
+		".TP\n" + `<\fIhost\fR>  required  string` + "\nhost ip or name\n",
+		".PP\n.nf\nusage:\n    example \\-t=32 host\n\\&.hidden\n\\&'quoted\n.fi\n",
+		".SH COPYRIGHT\nno copyright defined\n",
+		".SH VERSION\nVersion: 1.0.2\n.br\nVersionTime: 2018\\-09\\-01\n",
+	} {
+		if !strings.Contains(page, need) {
+			t.Errorf("GetManPage fail \nneed:\n%s\ngot:\n%s", need, page)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"os"
+	"strings"
+)
+
+//MsgId identifies a fixed text of usage page and error messages
+type MsgId int
+
+//Fixed texts of usage page and error messages, comments are their English formats
+const (
+	MsgUsageOf           MsgId = iota //"Usage of ([%s] Build [%s]):"
+	MsgUsageOfSet                     //"Usage of %s:"
+	MsgSummary                        //"Summary:"
+	MsgUsage                          //"Usage:"
+	MsgCopyRight                      //"CopyRight:"
+	MsgDetails                        //"Details:"
+	MsgGroupOptions                   //"%s options:"
+	MsgGroupSynopsis                  //"[%s options]"
+	MsgRequired                       //"required"
+	MsgDefault                        //"(default %s)"
+	MsgRange                          //"(range [%s, %s])"
+	MsgDeprecatedNote                 //"-%s is deprecated: %s"
+	MsgDeprecatedWarning              //"flag -%s is deprecated: %s"
+	MsgRequireMissing                 //"require but missing flag %s<%s>"
+	MsgBadSyntax                      //"bad flag syntax: %s"
+	MsgNotDefined                     //"flag provided but not defined: -%s"
+	MsgNoSuchFlag                     //"no such flag -%s"
+	MsgInvalidBoolValue               //"invalid boolean value %q for -%s: %v"
+	MsgInvalidBoolFlag                //"invalid boolean flag %s: %v"
+	MsgNeedsArgument                  //"flag needs an argument: -%s"
+	MsgInvalidValue                   //"invalid value %q for flag -%s: %v"
+	MsgResponseFile                   //"bad response file: %v"
+	MsgRejectedValue                  //"invalid value %q for flag %s<%s>: %v"
+	MsgPrompt                         //"%s (%s): "
+	MsgPromptMenu                     //"%s (%s):"
+	MsgPromptChoose                   //"choose 1-%d: "
+	MsgDuplicateFlag                  //"flag -%s at %s is already set by -%s at %s"
+)
+
+//Catalog provides format strings of fixed texts for a language
+type Catalog interface {
+	Message(id MsgId) string
+}
+
+//MapCatalog is a Catalog of a map, missing ones fall back to EnglishCatalog
+type MapCatalog map[MsgId]string
+
+//Message returns format string of id
+func (c MapCatalog) Message(id MsgId) string {
+	if s, ok := c[id]; ok {
+		return s
+	}
+	return EnglishCatalog[id]
+}
+
+//EnglishCatalog is the English texts
+var EnglishCatalog = MapCatalog{
+	MsgUsageOf:           "Usage of ([%s] Build [%s]):",
+	MsgUsageOfSet:        "Usage of %s:",
+	MsgSummary:           "Summary:",
+	MsgUsage:             "Usage:",
+	MsgCopyRight:         "CopyRight:",
+	MsgDetails:           "Details:",
+	MsgGroupOptions:      "%s options:",
+	MsgGroupSynopsis:     "[%s options]",
+	MsgRequired:          "required",
+	MsgDefault:           "(default %s)",
+	MsgRange:             "(range [%s, %s])",
+	MsgDeprecatedNote:    "-%s is deprecated: %s",
+	MsgDeprecatedWarning: "flag -%s is deprecated: %s",
+	MsgRequireMissing:    "require but missing flag %s<%s>",
+	MsgBadSyntax:         "bad flag syntax: %s",
+	MsgNotDefined:        "flag provided but not defined: -%s",
+	MsgNoSuchFlag:        "no such flag -%s",
+	MsgInvalidBoolValue:  "invalid boolean value %q for -%s: %v",
+	MsgInvalidBoolFlag:   "invalid boolean flag %s: %v",
+	MsgNeedsArgument:     "flag needs an argument: -%s",
+	MsgInvalidValue:      "invalid value %q for flag -%s: %v",
+	MsgResponseFile:      "bad response file: %v",
+	MsgRejectedValue:     "invalid value %q for flag %s<%s>: %v",
+	MsgPrompt:            "%s (%s): ",
+	MsgPromptMenu:        "%s (%s):",
+	MsgPromptChoose:      "choose 1-%d: ",
+	MsgDuplicateFlag:     "flag -%s at %s is already set by -%s at %s",
+}
+
+//ChineseCatalog is the simplified Chinese texts
+var ChineseCatalog = MapCatalog{
+	MsgUsageOf:           "([%s] 构建于 [%s]) 的用法:",
+	MsgUsageOfSet:        "%s 的用法:",
+	MsgSummary:           "概要:",
+	MsgUsage:             "用法:",
+	MsgCopyRight:         "版权:",
+	MsgDetails:           "详情:",
+	MsgGroupOptions:      "%s 选项:",
+	MsgGroupSynopsis:     "[%s 选项]",
+	MsgRequired:          "必需",
+	MsgDefault:           "(默认 %s)",
+	MsgRange:             "(范围 [%s, %s])",
+	MsgDeprecatedNote:    "-%s 已废弃: %s",
+	MsgDeprecatedWarning: "参数 -%s 已废弃: %s",
+	MsgRequireMissing:    "缺少必需参数 %s<%s>",
+	MsgBadSyntax:         "参数语法错误: %s",
+	MsgNotDefined:        "参数未定义: -%s",
+	MsgNoSuchFlag:        "参数不存在 -%s",
+	MsgInvalidBoolValue:  "布尔值 %q 对参数 -%s 无效: %v",
+	MsgInvalidBoolFlag:   "布尔参数 %s 无效: %v",
+	MsgNeedsArgument:     "参数需要一个值: -%s",
+	MsgInvalidValue:      "值 %q 对参数 -%s 无效: %v",
+	MsgResponseFile:      "参数文件错误: %v",
+	MsgRejectedValue:     "值 %q 对参数 %s<%s> 无效: %v",
+	MsgPrompt:            "请输入 %s (%s): ",
+	MsgPromptMenu:        "请选择 %s (%s):",
+	MsgPromptChoose:      "输入序号 1-%d: ",
+	MsgDuplicateFlag:     "参数 -%s (%s) 重复设置, 已由 -%s (%s) 设置",
+}
+
+//LangCatalog returns the built-in Catalog of language lang, as "zh_CN.UTF-8" format.
+//English is returned for unsupported ones.
+func LangCatalog(lang string) Catalog {
+	if strings.HasPrefix(strings.ToLower(lang), "zh") {
+		return ChineseCatalog
+	}
+	return EnglishCatalog
+}
+
+//envLang returns language of messages by environment, as POSIX locale rules
+func envLang() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang := os.Getenv(env); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+//SetCatalog set Catalog of usage page and error messages, returns the old one.
+//nil means choose by LC_ALL/LC_MESSAGES/LANG environment.
+func SetCatalog(c Catalog) (old Catalog) {
+	return CommandLine.SetCatalog(c)
+}
+
+//SetCatalog set Catalog of usage page and error messages, returns the old one.
+//nil means choose by LC_ALL/LC_MESSAGES/LANG environment.
+func (f *FlagSet) SetCatalog(c Catalog) (old Catalog) {
+	old, f.catalog = f.catalog, c
+	return
+}
+
+//msg returns format string of id
+func (f *FlagSet) msg(id MsgId) string {
+	if f.catalog != nil {
+		return f.catalog.Message(id)
+	}
+	return LangCatalog(envLang()).Message(id)
+}
@@ -0,0 +1,210 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+)
+
+// -- net.IP Value
+type ipValue net.IP
+
+func newIPValue(val net.IP, p *net.IP) *ipValue {
+	*p = val
+	return (*ipValue)(p)
+}
+
+func (i *ipValue) Set(s string) error {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return fmt.Errorf("invalid IP address %q", s)
+	}
+	*i = ipValue(ip)
+	return nil
+}
+
+func (i *ipValue) Get() interface{} { return net.IP(*i) }
+
+//reset sets nil for empty def, which Set can not parse
+func (i *ipValue) reset(def string) {
+	if def == "" {
+		*i = nil
+		return
+	}
+	i.Set(def)
+}
+
+func (i *ipValue) String() string {
+	if i == nil || len(*i) == 0 {
+		return ""
+	}
+	return net.IP(*i).String()
+}
+
+// -- net.IPNet Value
+type ipNetValue net.IPNet
+
+func newIPNetValue(val net.IPNet, p *net.IPNet) *ipNetValue {
+	*p = val
+	return (*ipNetValue)(p)
+}
+
+func (n *ipNetValue) Set(s string) error {
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return err
+	}
+	*n = ipNetValue(*ipNet)
+	return nil
+}
+
+func (n *ipNetValue) Get() interface{} { return net.IPNet(*n) }
+
+//reset sets the zero IPNet for empty def, which Set can not parse
+func (n *ipNetValue) reset(def string) {
+	if def == "" {
+		*n = ipNetValue{}
+		return
+	}
+	n.Set(def)
+}
+
+func (n *ipNetValue) String() string {
+	if n == nil || n.IP == nil {
+		return ""
+	}
+	return (*net.IPNet)(n).String()
+}
+
+// -- host:port Value
+type hostPortValue string
+
+func newHostPortValue(val string, p *string) *hostPortValue {
+	*p = val
+	return (*hostPortValue)(p)
+}
+
+func (h *hostPortValue) Set(s string) error {
+	_, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return err
+	}
+	if port == "" {
+		return fmt.Errorf("missing port in address %q", s)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		if _, err := net.LookupPort("tcp", port); err != nil {
+			return fmt.Errorf("invalid port %q", port)
+		}
+	}
+	*h = hostPortValue(s)
+	return nil
+}
+
+func (h *hostPortValue) Get() interface{} { return string(*h) }
+
+//reset sets the empty address for empty def, which Set can not parse
+func (h *hostPortValue) reset(def string) {
+	if def == "" {
+		*h = ""
+		return
+	}
+	h.Set(def)
+}
+
+func (h *hostPortValue) String() string { return string(*h) }
+
+// -- url.URL Value
+type urlValue url.URL
+
+func newURLValue(val url.URL, p *url.URL) *urlValue {
+	*p = val
+	return (*urlValue)(p)
+}
+
+func (u *urlValue) Set(s string) error {
+	v, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if v.Scheme == "" || v.Host == "" && v.Opaque == "" && v.Path == "" {
+		return fmt.Errorf("URL %q is not absolute", s)
+	}
+	*u = urlValue(*v)
+	return nil
+}
+
+func (u *urlValue) Get() interface{} { return url.URL(*u) }
+
+//reset sets the zero URL for empty def, which Set can not parse
+func (u *urlValue) reset(def string) {
+	if def == "" {
+		*u = urlValue{}
+		return
+	}
+	u.Set(def)
+}
+
+func (u *urlValue) String() string {
+	if u == nil {
+		return ""
+	}
+	return (*url.URL)(u).String()
+}
+
+//IPVar defines a net.IP flag with specified name, default value, and usage string.
+//The argument p points to a net.IP variable in which to store the value of the flag.
+//Values are parsed by net.ParseIP.
+func (f *FlagSet) IPVar(p *net.IP, name string, logic_name string, value net.IP, required bool, usage string) {
+	f.Var(newIPValue(value, p), name, logic_name, required, usage)
+}
+
+//IPVar defines a net.IP command line flag with specified name, default value, and usage string.
+//Values are parsed by net.ParseIP.
+func IPVar(p *net.IP, name string, logic_name string, value net.IP, required bool, usage string) {
+	CommandLine.Var(newIPValue(value, p), name, logic_name, required, usage)
+}
+
+//IPNetVar defines a net.IPNet flag with specified name, default value, and usage string.
+//The argument p points to a net.IPNet variable in which to store the value of the flag.
+//Values are CIDR notations as "192.168.0.0/16", parsed by net.ParseCIDR.
+func (f *FlagSet) IPNetVar(p *net.IPNet, name string, logic_name string, value net.IPNet, required bool, usage string) {
+	f.Var(newIPNetValue(value, p), name, logic_name, required, usage)
+}
+
+//IPNetVar defines a net.IPNet command line flag with specified name, default value, and usage string.
+//Values are CIDR notations as "192.168.0.0/16", parsed by net.ParseCIDR.
+func IPNetVar(p *net.IPNet, name string, logic_name string, value net.IPNet, required bool, usage string) {
+	CommandLine.Var(newIPNetValue(value, p), name, logic_name, required, usage)
+}
+
+//HostPortVar defines a "host:port" string flag with specified name, default value, and usage string.
+//Values are split by net.SplitHostPort, port must be a number or a known service name.
+func (f *FlagSet) HostPortVar(p *string, name string, logic_name string, value string, required bool, usage string) {
+	f.Var(newHostPortValue(value, p), name, logic_name, required, usage)
+}
+
+//HostPortVar defines a "host:port" string command line flag with specified name, default value, and usage string.
+//Values are split by net.SplitHostPort, port must be a number or a known service name.
+func HostPortVar(p *string, name string, logic_name string, value string, required bool, usage string) {
+	CommandLine.Var(newHostPortValue(value, p), name, logic_name, required, usage)
+}
+
+//URLVar defines a url.URL flag with specified name, default value, and usage string.
+//The argument p points to a url.URL variable in which to store the value of the flag.
+//Values must be absolute URLs.
+func (f *FlagSet) URLVar(p *url.URL, name string, logic_name string, value url.URL, required bool, usage string) {
+	f.Var(newURLValue(value, p), name, logic_name, required, usage)
+}
+
+//URLVar defines a url.URL command line flag with specified name, default value, and usage string.
+//Values must be absolute URLs.
+func URLVar(p *url.URL, name string, logic_name string, value url.URL, required bool, usage string) {
+	CommandLine.Var(newURLValue(value, p), name, logic_name, required, usage)
+}
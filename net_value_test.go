@@ -0,0 +1,91 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestNetFlags(t *testing.T) {
+	var (
+		ip     net.IP
+		subnet net.IPNet
+		addr   string
+		proxy  url.URL
+	)
+	cmd := cmdline.NewFlagSet("net", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.UsageWidth(80)
+	cmd.IPVar(&ip, "ip", "ip", net.IPv4(127, 0, 0, 1), false, "ip")
+	cmd.IPNetVar(&subnet, "net", "net", net.IPNet{}, false, "subnet")
+	cmd.HostPortVar(&addr, "listen", "listen", ":8080", false, "listen address")
+	cmd.URLVar(&proxy, "proxy", "proxy", url.URL{}, false, "proxy")
+
+	err := cmd.Parse([]string{"-ip=::1", "-net=10.1.2.3/8", "-listen=localhost:http", "-proxy=socks5://127.0.0.1:1080"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ip.Equal(net.IPv6loopback) || subnet.String() != "10.0.0.0/8" || addr != "localhost:http" ||
+		proxy.Scheme != "socks5" || proxy.Host != "127.0.0.1:1080" {
+		t.Errorf("ip=%v net=%v listen=%s proxy=%v", ip, subnet.String(), addr, proxy.String())
+	}
+
+	for _, arg := range []string{
+		"-ip=300.1.1.1",
+		"-net=10.0.0.0",
+		"-listen=localhost",
+		"-listen=localhost:",
+		"-listen=:70000",
+		"-proxy=/no/scheme",
+		"-proxy=http://[::1",
+	} {
+		if err := cmd.Parse([]string{arg}); err == nil {
+			t.Errorf("Parse(%s) need error", arg)
+		}
+	}
+
+	usage := cmd.GetUsage()
+	for _, need := range []string{
+		"  -ip=<ip>          ip         (default 127.0.0.1)\n",
+		"  -listen=<listen>  host:port  (default :8080)\n",
+		"  -net=<net>        cidr\n",
+		"  -proxy=<proxy>    url\n",
+	} {
+		if !strings.Contains(usage, need) {
+			t.Errorf("usage need %q, got:\n%s", need, usage)
+		}
+	}
+}
+
+func TestNetFlagsReset(t *testing.T) {
+	var (
+		ip     net.IP
+		subnet net.IPNet
+		addr   string
+		proxy  url.URL
+	)
+	cmd := cmdline.NewFlagSet("net", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.IPVar(&ip, "ip", "ip", nil, false, "ip")
+	cmd.IPNetVar(&subnet, "net", "net", net.IPNet{}, false, "subnet")
+	cmd.HostPortVar(&addr, "listen", "listen", "", false, "listen address")
+	cmd.URLVar(&proxy, "proxy", "proxy", url.URL{}, false, "proxy")
+
+	err := cmd.Parse([]string{"-ip=::1", "-net=10.1.2.3/8", "-listen=:80", "-proxy=http://host/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.Reset()
+	if ip != nil || subnet.IP != nil || addr != "" || proxy != (url.URL{}) {
+		t.Errorf("after Reset ip=%v net=%v listen=%q proxy=%v", ip, subnet.String(), addr, proxy.String())
+	}
+}
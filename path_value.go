@@ -0,0 +1,228 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//PathOptions are the checks of FileVar and DirVar flags
+type PathOptions struct {
+	MustExist  bool     //the path must exist
+	Readable   bool     //the path must exist and be readable
+	Extensions []string //allowed extensions of files as ".json", empty means any
+}
+
+//ExpandPath expands leading "~" to home directory and $VAR or ${VAR} to environment
+//variables of path, and makes it absolute against WorkDir()
+func ExpandPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + path[1:]
+		}
+	}
+	path = os.ExpandEnv(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(WorkDir(), path)
+	}
+	return filepath.Clean(path)
+}
+
+// -- file or directory path Value
+type pathValue struct {
+	p    *string
+	raw  string //path as it is given
+	dir  bool   //path of a directory but not a file
+	opts PathOptions
+}
+
+func newPathValue(val string, p *string, dir bool, opts PathOptions) *pathValue {
+	*p = ExpandPath(val)
+	return &pathValue{p: p, raw: val, dir: dir, opts: opts}
+}
+
+func (v *pathValue) Set(s string) error {
+	path := ExpandPath(s)
+	if err := v.check(path); err != nil {
+		return err
+	}
+	v.raw, *v.p = s, path
+	return nil
+}
+
+//check reports whether path meets the options
+func (v *pathValue) check(path string) error {
+	if len(v.opts.Extensions) > 0 && !v.dir {
+		ok := false
+		for _, ext := range v.opts.Extensions {
+			ok = ok || strings.EqualFold(filepath.Ext(path), ext)
+		}
+		if !ok {
+			return fmt.Errorf("%s: extension must be one of %s", path, strings.Join(v.opts.Extensions, " "))
+		}
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) && !v.opts.MustExist && !v.opts.Readable {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.IsDir() != v.dir {
+		if v.dir {
+			return fmt.Errorf("%s: not a directory", path)
+		}
+		return fmt.Errorf("%s: is a directory", path)
+	}
+	if v.opts.Readable {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		f.Close()
+	}
+	return nil
+}
+
+func (v *pathValue) Get() interface{} { return *v.p }
+
+//reset sets def without checking, as the default value is not checked
+func (v *pathValue) reset(def string) {
+	v.raw, *v.p = def, ExpandPath(def)
+}
+
+func (v *pathValue) target() interface{} { return v.p }
+
+func (v *pathValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.raw
+}
+
+//FileVar defines a file path flag with specified name, default value, and usage string.
+//The argument p points to a string variable in which to store the path expanded by ExpandPath.
+//Values are checked by opts, and must not be directories. The default value is not checked.
+func (f *FlagSet) FileVar(p *string, name string, logic_name string, value string, opts PathOptions, required bool, usage string) {
+	f.Var(newPathValue(value, p, false, opts), name, logic_name, required, usage)
+}
+
+//FileVar defines a file path command line flag with specified name, default value, and usage string.
+//Values are checked by opts, and must not be directories. The default value is not checked.
+func FileVar(p *string, name string, logic_name string, value string, opts PathOptions, required bool, usage string) {
+	CommandLine.Var(newPathValue(value, p, false, opts), name, logic_name, required, usage)
+}
+
+//DirVar defines a directory path flag with specified name, default value, and usage string.
+//The argument p points to a string variable in which to store the path expanded by ExpandPath.
+//Values are checked by opts, and must be directories if exist. The default value is not checked.
+func (f *FlagSet) DirVar(p *string, name string, logic_name string, value string, opts PathOptions, required bool, usage string) {
+	f.Var(newPathValue(value, p, true, opts), name, logic_name, required, usage)
+}
+
+//DirVar defines a directory path command line flag with specified name, default value, and usage string.
+//Values are checked by opts, and must be directories if exist. The default value is not checked.
+func DirVar(p *string, name string, logic_name string, value string, opts PathOptions, required bool, usage string) {
+	CommandLine.Var(newPathValue(value, p, true, opts), name, logic_name, required, usage)
+}
+
+// -- opened *os.File Value
+type openFileValue struct {
+	p     **os.File
+	flag  int         //flag of os.OpenFile
+	perm  os.FileMode //perm of os.OpenFile
+	owned bool        //*p is opened by Set but not stdin/stdout/default value
+	def   *os.File    //the default value
+}
+
+func newOpenFileValue(val *os.File, p **os.File, flag int, perm os.FileMode) *openFileValue {
+	*p = val
+	return &openFileValue{p: p, flag: flag, perm: perm, def: val}
+}
+
+func (v *openFileValue) Set(s string) error {
+	var file *os.File
+	owned := false
+	switch {
+	case s == "":
+	case s == "-" && v.flag&(os.O_WRONLY|os.O_RDWR) != 0:
+		file = os.Stdout
+	case s == "-":
+		file = os.Stdin
+	default:
+		var err error
+		if file, err = os.OpenFile(ExpandPath(s), v.flag, v.perm); err != nil {
+			return err
+		}
+		owned = true
+	}
+	if v.owned {
+		(*v.p).Close()
+	}
+	*v.p, v.owned = file, owned
+	return nil
+}
+
+//keep makes the next Set not close the current file, which is closed by release
+//or put back by restore, so a rejected file does not reopen and truncate the old one
+func (v *openFileValue) keep() (restore, release func()) {
+	file, owned := *v.p, v.owned
+	v.owned = false
+	restore = func() {
+		if v.owned {
+			(*v.p).Close()
+		}
+		*v.p, v.owned = file, owned
+	}
+	release = func() {
+		if owned {
+			file.Close()
+		}
+	}
+	return
+}
+
+func (v *openFileValue) Get() interface{} { return *v.p }
+
+//reset closes the file opened by Set and restores the default one, but not opens def again
+func (v *openFileValue) reset(def string) {
+	if v.owned {
+		(*v.p).Close()
+	}
+	*v.p, v.owned = v.def, false
+}
+
+func (v *openFileValue) target() interface{} { return v.p }
+
+func (v *openFileValue) String() string {
+	if v == nil || v.p == nil || *v.p == nil {
+		return ""
+	}
+	if *v.p == os.Stdin || *v.p == os.Stdout {
+		return "-"
+	}
+	return (*v.p).Name()
+}
+
+//OpenFileVar defines a flag of file opened by os.OpenFile with flag and perm.
+//The argument p points to a *os.File variable in which to store the opened file,
+//value is the default one. "-" means os.Stdin, or os.Stdout if flag is writable.
+//A file opened before is closed when the flag is set again.
+func (f *FlagSet) OpenFileVar(p **os.File, name string, logic_name string, value *os.File, flag int, perm os.FileMode, required bool, usage string) {
+	f.Var(newOpenFileValue(value, p, flag, perm), name, logic_name, required, usage)
+}
+
+//OpenFileVar defines a command line flag of file opened by os.OpenFile with flag and perm.
+//"-" means os.Stdin, or os.Stdout if flag is writable.
+func OpenFileVar(p **os.File, name string, logic_name string, value *os.File, flag int, perm os.FileMode, required bool, usage string) {
+	CommandLine.Var(newOpenFileValue(value, p, flag, perm), name, logic_name, required, usage)
+}
@@ -0,0 +1,174 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestExpandPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Setenv("CMDLINE_TEST_DIR", "data")
+	for _, c := range []struct {
+		path string
+		need string
+	}{
+		{"", ""},
+		{"~", home},
+		{"~/a/../b", filepath.Join(home, "b")},
+		{"$CMDLINE_TEST_DIR/x", filepath.Join(cmdline.WorkDir(), "data", "x")},
+		{"/abs/${CMDLINE_TEST_DIR}", "/abs/data"},
+	} {
+		if got := cmdline.ExpandPath(c.path); got != c.need {
+			t.Errorf("ExpandPath(%q) = %q, need %q", c.path, got, c.need)
+		}
+	}
+}
+
+func TestPathFlags(t *testing.T) {
+	dir := t.TempDir()
+	conf := filepath.Join(dir, "app.json")
+	if err := ioutil.WriteFile(conf, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var config, logDir, cache string
+	cmd := cmdline.NewFlagSet("path", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.UsageWidth(80)
+	cmd.FileVar(&config, "c", "config", "app.json", cmdline.PathOptions{Readable: true, Extensions: []string{".json"}}, false, "config")
+	cmd.DirVar(&logDir, "log", "log", "", cmdline.PathOptions{MustExist: true}, false, "log dir")
+	cmd.FileVar(&cache, "cache", "cache", "", cmdline.PathOptions{}, false, "cache")
+	cmd.DirVar(&logDir, "l", "log", "", cmdline.PathOptions{MustExist: true}, false, "synonym of -log")
+	if cmd.Lookup("l") != cmd.Lookup("log") {
+		t.Error("-l and -log of the same variable are not synonyms")
+	}
+
+	if config != filepath.Join(cmdline.WorkDir(), "app.json") {
+		t.Errorf("default config = %s", config)
+	}
+	if err := cmd.Parse([]string{"-c=" + conf, "-log=" + dir, "-cache=" + filepath.Join(dir, "none")}); err != nil {
+		t.Fatal(err)
+	}
+	if config != conf || logDir != dir || cache != filepath.Join(dir, "none") {
+		t.Errorf("config=%s log=%s cache=%s", config, logDir, cache)
+	}
+	for _, arg := range []string{
+		"-c=" + filepath.Join(dir, "none.json"),
+		"-c=" + filepath.Join(dir, "app.yaml"),
+		"-c=" + dir,
+		"-log=" + conf,
+		"-log=" + filepath.Join(dir, "none"),
+	} {
+		if err := cmd.Parse([]string{arg}); err == nil {
+			t.Errorf("Parse(%s) need error", arg)
+		}
+	}
+	if need := "  -c=<config>     file  (default app.json)\n"; !strings.Contains(cmd.GetUsage(), need) {
+		t.Errorf("usage need %q, got:\n%s", need, cmd.GetUsage())
+	}
+
+	cmd.Reset()
+	if config != filepath.Join(cmdline.WorkDir(), "app.json") || logDir != "" || cache != "" {
+		t.Errorf("after Reset config=%s log=%s cache=%s", config, logDir, cache)
+	}
+}
+
+func TestOpenFileVar(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.txt")
+	var in, w *os.File
+	cmd := cmdline.NewFlagSet("open", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.OpenFileVar(&in, "i", "input", os.Stdin, os.O_RDONLY, 0, false, "input")
+	cmd.OpenFileVar(&w, "o", "output", nil, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644, false, "output")
+	cmd.OpenFileVar(&w, "output", "output", w, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644, false, "synonym of -o")
+	if cmd.Lookup("output") != cmd.Lookup("o") {
+		t.Error("-o and -output of the same variable are not synonyms")
+	}
+	if in != os.Stdin || w != nil {
+		t.Fatalf("default in=%v out=%v", in, w)
+	}
+	if err := cmd.Parse([]string{"-o=" + out}); err != nil {
+		t.Fatal(err)
+	}
+	if w == nil || w.Name() != out {
+		t.Fatalf("out = %v", w)
+	}
+	w.WriteString("hello")
+	if err := cmd.Parse([]string{"-o=-"}); err != nil || w != os.Stdout {
+		t.Fatalf("Parse(-o=-) = %v, out=%v", err, w)
+	}
+	if b, err := ioutil.ReadFile(out); err != nil || string(b) != "hello" {
+		t.Errorf("file content %q, %v", b, err)
+	}
+
+	cmd.Validate("o", func(value string) error {
+		if strings.HasSuffix(value, ".bad") {
+			return errors.New("bad output")
+		}
+		return nil
+	})
+	if err := cmd.Parse([]string{"-o=" + out}); err != nil {
+		t.Fatal(err)
+	}
+	kept := w
+	if err := cmd.Parse([]string{"-o=" + out + ".bad"}); err == nil || w != kept {
+		t.Fatalf("Parse(-o=bad) = %v, out=%v", err, w)
+	}
+	if _, err := w.WriteString("hello"); err != nil {
+		t.Errorf("rolled back file is closed: %v", err)
+	}
+	if b, err := ioutil.ReadFile(out); err != nil || string(b) != "hello" {
+		t.Errorf("rolled back file content %q, %v", b, err)
+	}
+	if err := cmd.Parse([]string{"-o=-"}); err != nil || w != os.Stdout {
+		t.Fatalf("Parse(-o=-) = %v, out=%v", err, w)
+	}
+	if err := kept.Close(); err == nil {
+		t.Error("file replaced by Parse is not closed")
+	}
+	if err := cmd.Parse([]string{"-i=" + filepath.Join(filepath.Dir(out), "none")}); err == nil {
+		t.Error("open missing input need error")
+	}
+
+	if err := cmd.Parse([]string{"-i=" + out}); err != nil {
+		t.Fatal(err)
+	}
+	opened := in
+	cmd.Reset()
+	if in != os.Stdin || w != nil {
+		t.Errorf("after Reset in=%v out=%v", in, w)
+	}
+	if err := opened.Close(); err == nil {
+		t.Error("file opened by Parse is not closed by Reset")
+	}
+
+	def, err := os.OpenFile(out, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer def.Close()
+	var log *os.File
+	cmd.OpenFileVar(&log, "log", "log", def, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644, false, "log")
+	if err := cmd.Parse([]string{"-log=" + out + ".log"}); err != nil {
+		t.Fatal(err)
+	}
+	cmd.Reset()
+	if b, err := ioutil.ReadFile(out); log != def || err != nil || string(b) != "hello" {
+		t.Errorf("after Reset log=%v, default file content %q, %v", log, b, err)
+	}
+}
@@ -0,0 +1,196 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// -- *regexp.Regexp Value
+type regexpValue struct {
+	p **regexp.Regexp
+}
+
+func newRegexpValue(val *regexp.Regexp, p **regexp.Regexp) *regexpValue {
+	*p = val
+	return &regexpValue{p: p}
+}
+
+func (v *regexpValue) Set(s string) error {
+	re, err := regexp.Compile(s)
+	if err != nil {
+		pos := 0
+		if e, ok := err.(*syntax.Error); ok {
+			pos = regexpErrorPos(s, e)
+		}
+		return &SyntaxError{Line: s, Pos: pos, Msg: err.Error()}
+	}
+	*v.p = re
+	return nil
+}
+
+func (v *regexpValue) Get() interface{} { return *v.p }
+
+//reset sets nil for empty def, as Set of it compiles a regexp matches everything
+func (v *regexpValue) reset(def string) {
+	if def == "" {
+		*v.p = nil
+		return
+	}
+	v.Set(def)
+}
+
+func (v *regexpValue) target() interface{} { return v.p }
+
+func (v *regexpValue) String() string {
+	if v == nil || v.p == nil || *v.p == nil {
+		return ""
+	}
+	return (*v.p).String()
+}
+
+// -- glob pattern Value
+type globValue string
+
+func newGlobValue(val string, p *string) *globValue {
+	*p = val
+	return (*globValue)(p)
+}
+
+func (g *globValue) Set(s string) error {
+	if _, err := filepath.Match(s, ""); err != nil {
+		return &SyntaxError{Line: s, Pos: globErrorPos(s), Msg: err.Error()}
+	}
+	*g = globValue(s)
+	return nil
+}
+
+func (g *globValue) Get() interface{} { return string(*g) }
+
+func (g *globValue) String() string { return string(*g) }
+
+//regexpErrorPos returns the position of err in regular expression expr.
+//Unbalanced parens are found by scanning expr, as err shows the whole expr for them;
+//other errors are at the first place err.Expr shows, in or out of character classes by its code.
+func regexpErrorPos(expr string, err *syntax.Error) int {
+	switch err.Code {
+	case syntax.ErrMissingBracket: //Expr is the rest of expr from the bad class
+		return len(expr) - len(err.Expr)
+	case syntax.ErrTrailingBackslash:
+		return len(expr) - 1
+	}
+	parenErr := err.Code == syntax.ErrMissingParen || err.Code == syntax.ErrUnexpectedParen
+	classErr := err.Code == syntax.ErrInvalidCharRange || err.Code == syntax.ErrInvalidCharClass
+	var parens []int //positions of open parens
+	class := -1      //position of '[' of the class being scanned
+	for i := 0; i < len(expr); i++ {
+		if !parenErr && strings.HasPrefix(expr[i:], err.Expr) &&
+			((class >= 0) == classErr || err.Code == syntax.ErrInvalidEscape) {
+			return i
+		}
+		switch c := expr[i]; {
+		case strings.HasPrefix(expr[i:], `\Q`): //quoted text ends at \E
+			if end := strings.Index(expr[i:], `\E`); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(expr)
+			}
+		case c == '\\':
+			i++
+		case class >= 0:
+			if strings.HasPrefix(expr[i:], "[:") { //POSIX class as [:alpha:]
+				if end := strings.Index(expr[i:], ":]"); end >= 0 {
+					i += end + 1
+				}
+			} else if c == ']' && i > class+1 && !(i == class+2 && expr[class+1] == '^') {
+				class = -1
+			}
+		case c == '[':
+			class = i
+		case c == '(':
+			parens = append(parens, i)
+		case c == ')':
+			if len(parens) == 0 {
+				return i
+			}
+			parens = parens[:len(parens)-1]
+		}
+	}
+	if len(parens) > 0 {
+		return parens[len(parens)-1]
+	}
+	return 0
+}
+
+//globErrorPos returns the position of the character class or escape
+//that makes glob pattern bad
+func globErrorPos(pattern string) int {
+	escape := os.PathSeparator != '\\'
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if escape {
+				if i+1 == len(pattern) {
+					return i
+				}
+				i++
+			}
+		case '[':
+			end := globClassEnd(pattern, i+1, escape)
+			if end < 0 {
+				return i
+			}
+			if _, err := filepath.Match(pattern[i:end+1], ""); err != nil {
+				return i
+			}
+			i = end
+		}
+	}
+	return 0
+}
+
+//globClassEnd returns the position of ']' closing a character class begins at start, or -1
+func globClassEnd(pattern string, start int, escape bool) int {
+	for i := start; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == ']':
+			return i
+		case pattern[i] == '\\' && escape:
+			i++
+		}
+	}
+	return -1
+}
+
+//RegexpVar defines a *regexp.Regexp flag with specified name, default value, and usage string.
+//The argument p points to a *regexp.Regexp variable in which to store the compiled pattern.
+//Bad patterns are rejected with *SyntaxError.
+func (f *FlagSet) RegexpVar(p **regexp.Regexp, name string, logic_name string, value *regexp.Regexp, required bool, usage string) {
+	f.Var(newRegexpValue(value, p), name, logic_name, required, usage)
+}
+
+//RegexpVar defines a *regexp.Regexp command line flag with specified name, default value, and usage string.
+//Bad patterns are rejected with *SyntaxError.
+func RegexpVar(p **regexp.Regexp, name string, logic_name string, value *regexp.Regexp, required bool, usage string) {
+	CommandLine.Var(newRegexpValue(value, p), name, logic_name, required, usage)
+}
+
+//GlobVar defines a glob pattern flag of filepath.Match with specified name, default value, and usage string.
+//The argument p points to a string variable in which to store the pattern.
+//Bad patterns are rejected with *SyntaxError.
+func (f *FlagSet) GlobVar(p *string, name string, logic_name string, value string, required bool, usage string) {
+	f.Var(newGlobValue(value, p), name, logic_name, required, usage)
+}
+
+//GlobVar defines a glob pattern command line flag of filepath.Match with specified name, default value, and usage string.
+//Bad patterns are rejected with *SyntaxError.
+func GlobVar(p *string, name string, logic_name string, value string, required bool, usage string) {
+	CommandLine.Var(newGlobValue(value, p), name, logic_name, required, usage)
+}
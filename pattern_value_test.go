@@ -0,0 +1,98 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestPatternFlags(t *testing.T) {
+	var (
+		re   *regexp.Regexp
+		glob string
+	)
+	cmd := cmdline.NewFlagSet("pattern", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.UsageWidth(80)
+	cmd.RegexpVar(&re, "e", "regexp", regexp.MustCompile(`^\w+$`), false, "pattern")
+	cmd.GlobVar(&glob, "g", "glob", "*.go", false, "files")
+	cmd.RegexpVar(&re, "regexp", "regexp", re, false, "synonym of -e")
+	if cmd.Lookup("regexp") != cmd.Lookup("e") {
+		t.Error("-e and -regexp of the same variable are not synonyms")
+	}
+
+	if err := cmd.Parse([]string{"-e=err(or)?", "-g=[a-c]*.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString("errors") || glob != "[a-c]*.txt" {
+		t.Errorf("regexp=%v glob=%s", re, glob)
+	}
+
+	for _, c := range []struct {
+		arg string
+		pos int
+	}{
+		{"-e=ab[c", 2},
+		{"-e=x*+", 1},
+		{"-e=[*+]x*+", 5},
+		{"-e=ab)c", 2},
+		{"-e=a(b", 1},
+		{"-e=(a(b)", 0},
+		{"-e=[[:alpha:]()]\\8", 13},
+		{"-e=[a-z](x[b-a])", 8},
+		{"-e=a{2,1}", 1},
+		{"-g=a[b", 1},
+		{"-g=ab[]c]", 2},
+		{"-g=ab[c]d[", 6},
+	} {
+		err := cmd.Parse([]string{c.arg})
+		if err == nil {
+			t.Errorf("Parse(%s) need error", c.arg)
+			continue
+		}
+		var se *cmdline.SyntaxError
+		if !errors.As(err, &se) || se.Pos != c.pos {
+			t.Errorf("Parse(%s) = %v, need *SyntaxError at position %d", c.arg, err, c.pos)
+		}
+	}
+	e := cmd.Set("e", "(a")
+	if se, ok := e.(*cmdline.SyntaxError); !ok || se.Pos != 0 || se.Line != "(a" {
+		t.Errorf("Set(e, (a) = %#v", e)
+	}
+	if !re.MatchString("error") {
+		t.Error("bad pattern changed value")
+	}
+
+	usage := cmd.GetUsage()
+	for _, need := range []string{
+		"  -e|regexp=<regexp>  regexp  (default ^\\w+$)\n",
+		"  -g=<glob>           glob    (default *.go)\n",
+	} {
+		if !strings.Contains(usage, need) {
+			t.Errorf("usage need %q, got:\n%s", need, usage)
+		}
+	}
+}
+
+func TestRegexpVarReset(t *testing.T) {
+	var re *regexp.Regexp
+	cmd := cmdline.NewFlagSet("pattern", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.RegexpVar(&re, "e", "regexp", nil, false, "pattern")
+	if err := cmd.Parse([]string{"-e=^a"}); err != nil || re == nil {
+		t.Fatalf("Parse(-e=^a) = %v, regexp=%v", err, re)
+	}
+	cmd.Reset()
+	if re != nil {
+		t.Errorf("after Reset regexp=%v, need nil", re)
+	}
+}
@@ -0,0 +1,113 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//PromptMissing makes Parse prompt for missing required command line flags
+//instead of failing. It returns the old setting.
+func PromptMissing(enable bool) (old bool) {
+	return CommandLine.PromptMissing(enable)
+}
+
+//PromptMissing makes Parse prompt for missing required flags instead of failing,
+//if stdin is a terminal or PromptInput is assigned. It returns the old setting.
+//Prompts show LogicName and usage of flags, input of secret flags is hidden,
+//and a Chooser flag shows a menu of its choices.
+func (f *FlagSet) PromptMissing(enable bool) (old bool) {
+	old, f.prompt = f.prompt, enable
+	return
+}
+
+//PromptInput set where prompted values of command line flags are read from,
+//returns the old one. nil means os.Stdin.
+func PromptInput(in io.Reader) (old io.Reader) {
+	return CommandLine.PromptInput(in)
+}
+
+//PromptInput set where prompted values are read from, returns the old one.
+//nil means os.Stdin, which is prompted only if it is a terminal.
+func (f *FlagSet) PromptInput(in io.Reader) (old io.Reader) {
+	old, f.promptIn = f.promptIn, in
+	return
+}
+
+//canPrompt reports whether missing flags can be prompted
+func (f *FlagSet) canPrompt() bool {
+	return f.prompt && (f.promptIn != nil || isTerminal(os.Stdin.Fd()))
+}
+
+//promptReader returns the reader of prompted values
+func (f *FlagSet) promptReader() *bufio.Reader {
+	if f.promptIn != nil {
+		return bufio.NewReader(f.promptIn)
+	}
+	return bufio.NewReader(os.Stdin)
+}
+
+//promptFlag asks for value of flag until it is accepted, or input is ended
+func (f *FlagSet) promptFlag(flag *Flag, in *bufio.Reader) error {
+	out := f.Output()
+	_, usage := UnquoteUsage(flag)
+	var choices []string
+	if c, ok := flag.Value.(Chooser); ok {
+		choices = c.Choices()
+	}
+	for {
+		if len(choices) > 0 {
+			fmt.Fprintf(out, f.msg(MsgPromptMenu)+"\n", flag.LogicName, usage)
+			for i, c := range choices {
+				fmt.Fprintf(out, "  %d) %s\n", i+1, c)
+			}
+			fmt.Fprintf(out, f.msg(MsgPromptChoose), len(choices))
+		} else {
+			fmt.Fprintf(out, f.msg(MsgPrompt), flag.LogicName, usage)
+		}
+
+		line, err := f.readPrompted(flag, in)
+		if line == "" && err != nil {
+			fmt.Fprintln(out)
+			return err
+		}
+		if n, e := strconv.Atoi(line); e == nil && n >= 1 && n <= len(choices) {
+			line = choices[n-1]
+		}
+		if line == "" {
+			continue
+		}
+		if e := f.setValue(flag, flag.Name, line, Source{Origin: OriginPrompt}); e != nil {
+			if he, ok := e.(*hookError); ok {
+				e = he.err
+			}
+			fmt.Fprintf(out, f.msg(MsgRejectedValue)+"\n", flag.mask(line), flag.GetShowName(), flag.LogicName, e)
+			continue
+		}
+		return nil
+	}
+}
+
+//readPrompted reads a line of value, without echo for secret flags on terminal
+func (f *FlagSet) readPrompted(flag *Flag, in *bufio.Reader) (string, error) {
+	if flag.Secret && f.promptIn == nil {
+		fd := os.Stdin.Fd()
+		if err := setEcho(fd, false); err != nil {
+			return "", err
+		}
+		defer func() {
+			setEcho(fd, true)
+			fmt.Fprintln(f.Output())
+		}()
+	}
+	line, err := in.ReadString('\n')
+	return strings.TrimRight(line, "\r\n"), err
+}
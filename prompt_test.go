@@ -0,0 +1,102 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestPromptMissing(t *testing.T) {
+	var out bytes.Buffer
+	var mode string
+	cmd := cmdline.NewFlagSet("prompt", cmdline.ContinueOnError)
+	cmd.SetOutput(&out)
+	cmd.UsageWidth(80)
+	cmd.EnumVar(&mode, "m", "mode", "", []string{"fast", "slow"}, true, "run mode")
+	ttl := cmd.Int("t", "ttl", 0, true, "TTL of packets")
+	password := cmd.String("p", "password", "", true, "password of user")
+	cmd.Secret("p")
+	cmd.PromptMissing(true)
+	cmd.PromptInput(strings.NewReader("2\nenv:PATH\nabc\n\n64\n"))
+	if err := cmd.Parse([]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if mode != "slow" || *ttl != 64 || *password != "env:PATH" {
+		t.Errorf("mode=%s ttl=%d password=%s", mode, *ttl, *password)
+	}
+	if origin := cmd.Lookup("p").Source().Origin; origin != cmdline.OriginPrompt {
+		t.Errorf("typed password is from %s", origin)
+	}
+	need := `mode (run mode):
+  1) fast
+  2) slow
+choose 1-2: password (password of user): ttl (TTL of packets): invalid value "abc" for flag -t=<ttl>: strconv.ParseInt: parsing "abc": invalid syntax
+ttl (TTL of packets): ttl (TTL of packets): `
+	if out.String() != need {
+		t.Errorf("prompt output\nneed:\n%s\ngot:\n%s", need, out.String())
+	}
+}
+
+func TestPromptPositional(t *testing.T) {
+	var out bytes.Buffer
+	var count int
+	cmd := cmdline.NewFlagSet("prompt", cmdline.ContinueOnError)
+	cmd.SetOutput(&out)
+	cmd.IntVar(&count, "", "count", 0, true, "count of requests")
+	cmd.PromptMissing(true)
+	cmd.PromptInput(strings.NewReader("x\n3\n"))
+	if err := cmd.Parse([]string{}); err != nil || count != 3 {
+		t.Fatalf("Parse() = %v, count=%d", err, count)
+	}
+	need := `invalid value "x" for flag <count>: strconv.ParseInt: parsing "x": invalid syntax`
+	if !strings.Contains(out.String(), need) {
+		t.Errorf("prompt output need %q, got:\n%s", need, out.String())
+	}
+}
+
+func TestPromptEndOfInput(t *testing.T) {
+	var mode string
+	cmd := cmdline.NewFlagSet("prompt", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.EnumVar(&mode, "m", "mode", "", []string{"fast", "slow"}, true, "run mode")
+	cmd.Int("t", "ttl", 0, true, "TTL of packets")
+	cmd.String("p", "password", "", true, "password of user")
+	cmd.PromptMissing(true)
+	cmd.PromptInput(strings.NewReader("fast\n"))
+	err := cmd.Parse([]string{"-t=1"})
+	if need := "require but missing flag -p=<password>"; err == nil || err.Error() != need {
+		t.Errorf("Parse() = %v, need %s", err, need)
+	}
+}
+
+func TestEnumVar(t *testing.T) {
+	var mode string
+	cmd := cmdline.NewFlagSet("enum", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.UsageWidth(80)
+	cmd.EnumVar(&mode, "m", "mode", "", []string{"fast", "slow"}, true, "run mode")
+	cmd.EnumVar(&mode, "mode", "mode", mode, []string{"fast", "slow"}, true, "synonym of -m")
+	if cmd.Lookup("mode") != cmd.Lookup("m") {
+		t.Error("-m and -mode of the same variable are not synonyms")
+	}
+	if err := cmd.Parse([]string{"-m=medium"}); err == nil {
+		t.Error("Parse(-m=medium) need error")
+	}
+	if err := cmd.Parse([]string{"-m=fast"}); err != nil || mode != "fast" {
+		t.Errorf("Parse(-m=fast) = %v, mode=%s", err, mode)
+	}
+	if need := "  -m|mode=<mode>  required  fast|slow\n"; !strings.Contains(cmd.GetUsage(), need) {
+		t.Errorf("usage need %q, got:\n%s", need, cmd.GetUsage())
+	}
+	cmd.Reset()
+	if mode != "" {
+		t.Errorf("after Reset mode=%s, need empty", mode)
+	}
+}
@@ -0,0 +1,142 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"fmt"
+	"time"
+)
+
+//RangeError reports a value out of the range of a flag
+type RangeError struct {
+	Value string //the value
+	Min   string //lower bound
+	Max   string //upper bound
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("%s is out of range [%s, %s]", e.Value, e.Min, e.Max)
+}
+
+//rangeValue is a Value bounded in [min, max]
+type rangeValue struct {
+	Value
+	min, max string      //bounds as text
+	inRange  func() bool //reports whether current value is in range
+}
+
+func newRangeValue(value Value, min, max interface{}, inRange func() bool) *rangeValue {
+	return &rangeValue{Value: value, min: fmt.Sprint(min), max: fmt.Sprint(max), inRange: inRange}
+}
+
+func (r *rangeValue) Set(s string) error {
+	old := r.Value.String()
+	if err := r.Value.Set(s); err != nil {
+		return err
+	}
+	if !r.inRange() {
+		err := &RangeError{Value: r.Value.String(), Min: r.min, Max: r.max}
+		r.Value.Set(old)
+		return err
+	}
+	return nil
+}
+
+func (r *rangeValue) Get() interface{} { return r.Value.(Getter).Get() }
+
+func (r *rangeValue) target() interface{} {
+	if t, ok := r.Value.(targeter); ok {
+		return t.target()
+	}
+	return r.Value
+}
+
+//rangeVar defines a flag of value, it panics if the default value is out of range
+func (f *FlagSet) rangeVar(method string, value *rangeValue, name string, logic_name string, required bool, usage string) {
+	if !value.inRange() {
+		msg := fmt.Sprintf("%s: default value %s of flag %s is out of range [%s, %s]", method, value.String(), name, value.min, value.max)
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	f.Var(value, name, logic_name, required, usage)
+}
+
+//Range returns the bounds of flag value as text, ok is false if it is not bounded
+func (f *Flag) Range() (min, max string, ok bool) {
+	if r, isRange := f.Value.(*rangeValue); isRange {
+		return r.min, r.max, true
+	}
+	return
+}
+
+//IntRangeVar defines an int flag bounded in [min, max].
+//Values out of range are rejected with *RangeError. It panics if the default value is out of range.
+func (f *FlagSet) IntRangeVar(p *int, name string, logic_name string, value int, min int, max int, required bool, usage string) {
+	f.rangeVar("IntRangeVar", newRangeValue(newIntValue(value, p), min, max, func() bool { return *p >= min && *p <= max }), name, logic_name, required, usage)
+}
+
+//IntRangeVar defines an int command line flag bounded in [min, max]
+func IntRangeVar(p *int, name string, logic_name string, value int, min int, max int, required bool, usage string) {
+	CommandLine.IntRangeVar(p, name, logic_name, value, min, max, required, usage)
+}
+
+//Int64RangeVar defines an int64 flag bounded in [min, max].
+//Values out of range are rejected with *RangeError. It panics if the default value is out of range.
+func (f *FlagSet) Int64RangeVar(p *int64, name string, logic_name string, value int64, min int64, max int64, required bool, usage string) {
+	f.rangeVar("Int64RangeVar", newRangeValue(newInt64Value(value, p), min, max, func() bool { return *p >= min && *p <= max }), name, logic_name, required, usage)
+}
+
+//Int64RangeVar defines an int64 command line flag bounded in [min, max]
+func Int64RangeVar(p *int64, name string, logic_name string, value int64, min int64, max int64, required bool, usage string) {
+	CommandLine.Int64RangeVar(p, name, logic_name, value, min, max, required, usage)
+}
+
+//UintRangeVar defines a uint flag bounded in [min, max].
+//Values out of range are rejected with *RangeError. It panics if the default value is out of range.
+func (f *FlagSet) UintRangeVar(p *uint, name string, logic_name string, value uint, min uint, max uint, required bool, usage string) {
+	f.rangeVar("UintRangeVar", newRangeValue(newUintValue(value, p), min, max, func() bool { return *p >= min && *p <= max }), name, logic_name, required, usage)
+}
+
+//UintRangeVar defines a uint command line flag bounded in [min, max]
+func UintRangeVar(p *uint, name string, logic_name string, value uint, min uint, max uint, required bool, usage string) {
+	CommandLine.UintRangeVar(p, name, logic_name, value, min, max, required, usage)
+}
+
+//Uint64RangeVar defines a uint64 flag bounded in [min, max].
+//Values out of range are rejected with *RangeError. It panics if the default value is out of range.
+func (f *FlagSet) Uint64RangeVar(p *uint64, name string, logic_name string, value uint64, min uint64, max uint64, required bool, usage string) {
+	f.rangeVar("Uint64RangeVar", newRangeValue(newUint64Value(value, p), min, max, func() bool { return *p >= min && *p <= max }), name, logic_name, required, usage)
+}
+
+//Uint64RangeVar defines a uint64 command line flag bounded in [min, max]
+func Uint64RangeVar(p *uint64, name string, logic_name string, value uint64, min uint64, max uint64, required bool, usage string) {
+	CommandLine.Uint64RangeVar(p, name, logic_name, value, min, max, required, usage)
+}
+
+//Float64RangeVar defines a float64 flag bounded in [min, max].
+//Values out of range are rejected with *RangeError. It panics if the default value is out of range.
+func (f *FlagSet) Float64RangeVar(p *float64, name string, logic_name string, value float64, min float64, max float64, required bool, usage string) {
+	f.rangeVar("Float64RangeVar", newRangeValue(newFloat64Value(value, p), min, max, func() bool { return *p >= min && *p <= max }), name, logic_name, required, usage)
+}
+
+//Float64RangeVar defines a float64 command line flag bounded in [min, max]
+func Float64RangeVar(p *float64, name string, logic_name string, value float64, min float64, max float64, required bool, usage string) {
+	CommandLine.Float64RangeVar(p, name, logic_name, value, min, max, required, usage)
+}
+
+//DurationRangeVar defines a time.Duration flag bounded in [min, max].
+//Values out of range are rejected with *RangeError. It panics if the default value is out of range.
+func (f *FlagSet) DurationRangeVar(p *time.Duration, name string, logic_name string, value time.Duration, min time.Duration, max time.Duration, required bool, usage string) {
+	f.rangeVar("DurationRangeVar", newRangeValue(newDurationValue(value, p), min, max, func() bool { return *p >= min && *p <= max }), name, logic_name, required, usage)
+}
+
+//DurationRangeVar defines a time.Duration command line flag bounded in [min, max]
+func DurationRangeVar(p *time.Duration, name string, logic_name string, value time.Duration, min time.Duration, max time.Duration, required bool, usage string) {
+	CommandLine.DurationRangeVar(p, name, logic_name, value, min, max, required, usage)
+}
@@ -0,0 +1,102 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestRangeFlags(t *testing.T) {
+	var (
+		ttl     int
+		size    uint64
+		ratio   float64
+		timeout time.Duration
+	)
+	cmd := cmdline.NewFlagSet("range", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.UsageWidth(80)
+	cmd.IntRangeVar(&ttl, "t", "ttl", 64, 1, 255, false, "TTL")
+	cmd.Uint64RangeVar(&size, "s", "size", 0, 0, 65500, false, "size")
+	cmd.Float64RangeVar(&ratio, "r", "ratio", 0.5, 0, 1, false, "ratio")
+	cmd.DurationRangeVar(&timeout, "w", "timeout", time.Second, time.Millisecond, time.Minute, false, "timeout")
+
+	if err := cmd.Parse([]string{"-t=255", "-s=100", "-r=1", "-w=30s"}); err != nil {
+		t.Fatal(err)
+	}
+	if ttl != 255 || size != 100 || ratio != 1 || timeout != 30*time.Second {
+		t.Errorf("ttl=%d size=%d ratio=%v timeout=%v", ttl, size, ratio, timeout)
+	}
+	if n := cmd.GetInt("t"); n != 255 {
+		t.Errorf("GetInt(t) = %d", n)
+	}
+
+	for _, c := range []struct {
+		arg  string
+		need string
+	}{
+		{"-t=0", `invalid value "0" for flag -t: 0 is out of range [1, 255]`},
+		{"-r=1.5", `invalid value "1.5" for flag -r: 1.5 is out of range [0, 1]`},
+		{"-w=2m", `invalid value "2m" for flag -w: 2m0s is out of range [1ms, 1m0s]`},
+	} {
+		err := cmd.Parse([]string{c.arg})
+		if err == nil || err.Error() != c.need {
+			t.Errorf("Parse(%s) = %v, need %s", c.arg, err, c.need)
+		}
+		var re *cmdline.RangeError
+		if !errors.As(err, &re) {
+			t.Errorf("Parse(%s) error %T does not wrap *RangeError", c.arg, err)
+		}
+	}
+	if ttl != 255 || ratio != 1 || timeout != 30*time.Second {
+		t.Errorf("out of range values are not rejected: ttl=%d ratio=%v timeout=%v", ttl, ratio, timeout)
+	}
+	if err := cmd.Set("s", "65501"); err == nil {
+		t.Error("Set(s, 65501) need error")
+	} else if _, ok := err.(*cmdline.RangeError); !ok {
+		t.Errorf("Set(s, 65501) error is %T, need *RangeError", err)
+	}
+
+	usage := cmd.GetUsage()
+	for _, need := range []string{
+		"  -t=<ttl>      int       (default 64) (range [1, 255])\n",
+		"  -s=<size>     uint      (range [0, 65500])\n",
+		"  -w=<timeout>  duration  (default 1s) (range [1ms, 1m0s])\n",
+	} {
+		if !strings.Contains(usage, need) {
+			t.Errorf("usage need %q, got:\n%s", need, usage)
+		}
+	}
+}
+
+func TestRangeSynonyms(t *testing.T) {
+	var ttl int
+	cmd := cmdline.NewFlagSet("range", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.IntRangeVar(&ttl, "t", "ttl", 64, 1, 255, false, "TTL")
+	cmd.IntRangeVar(&ttl, "ttl", "ttl", ttl, 1, 255, false, "TTL")
+	if flag := cmd.Lookup("ttl"); flag != cmd.Lookup("t") || len(flag.Synonyms) != 2 {
+		t.Errorf("-t and -ttl of the same variable are not synonyms")
+	}
+	if err := cmd.Parse([]string{"-ttl=300"}); err == nil {
+		t.Error("out of range value of synonym need error")
+	}
+
+	defer func() {
+		need := "range IntRangeVar: default value 0 of flag c is out of range [1, 10]"
+		if r := recover(); r != need {
+			t.Errorf("panic = %v, need %s", r, need)
+		}
+	}()
+	var count int
+	cmd.IntRangeVar(&count, "c", "count", 0, 1, 10, false, "count")
+}
@@ -0,0 +1,191 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+)
+
+//docFlag is the info of a flag shown in reference docs
+type docFlag struct {
+	Anchor     string
+	Name       string   //as "-f|flag=<name>" in usage page
+	Synonyms   []string //as "-f"
+	Type       string
+	Default    string
+	Required   bool
+	Usage      string
+	Deprecated []string //deprecation notes
+}
+
+//docSection is a group of flags in reference docs
+type docSection struct {
+	Title string
+	Flags []docFlag
+}
+
+//docSections returns flags info of reference docs, one section per group
+func (f *FlagSet) docSections() []docSection {
+	var sections []docSection
+	for i, flag := range f.usageFlags() {
+		if i == 0 || sections[len(sections)-1].Title != flag.Group {
+			sections = append(sections, docSection{Title: flag.Group})
+		}
+		d := docFlag{
+			Name:     fmt.Sprintf("%s<%s>", flag.GetShowName(), flag.LogicName),
+			Required: flag.Required,
+		}
+		if strings.HasPrefix(flag.Name, gNoNamePrefix) {
+			d.Anchor = "arg-" + flag.LogicName
+		} else {
+			d.Anchor = "flag-" + flag.Name
+			for _, v := range flag.visibleSynonyms() {
+				d.Synonyms = append(d.Synonyms, "-"+v)
+				if note := flag.Deprecation(v); note != "" {
+					d.Deprecated = append(d.Deprecated, fmt.Sprintf("-%s is deprecated: %s", v, note))
+				}
+			}
+		}
+		d.Type, d.Usage = flag.typeName, flag.text
+		if flag.showDef {
+			d.Default = flag.DefValue
+		}
+		section := &sections[len(sections)-1]
+		section.Flags = append(section.Flags, d)
+	}
+	return sections
+}
+
+//GetMarkdown returns the reference doc of command in Markdown format
+func GetMarkdown() string {
+	return CommandLine.GetMarkdown()
+}
+
+//GetMarkdown returns the reference doc of command in Markdown format.
+//Every flag has an anchor as "#flag-name", or "#arg-logicname" for no-name ones.
+func (f *FlagSet) GetMarkdown() string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("# %s\n\n", thisCmd))
+	if !isNoneInfo(f.summary) {
+		buf.WriteString(fmt.Sprintf("%s\n\n", strings.TrimSpace(f.summary)))
+	}
+
+	buf.WriteString("## Usage\n\n```\n")
+	buf.WriteString(thisCmd)
+	for _, item := range synopsisItems(f.usageFlags(), EnglishCatalog[MsgGroupSynopsis]) {
+		buf.WriteString(" ")
+		buf.WriteString(item)
+	}
+	buf.WriteString("\n```\n")
+
+	for _, section := range f.docSections() {
+		if section.Title == "" {
+			buf.WriteString("\n## Options\n\n")
+		} else {
+			buf.WriteString(fmt.Sprintf("\n### %s options\n\n", section.Title))
+		}
+		buf.WriteString("| Flag | Synonyms | Type | Default | Required | Description |\n")
+		buf.WriteString("| ---- | -------- | ---- | ------- | -------- | ----------- |\n")
+		for _, d := range section.Flags {
+			synonyms := make([]string, len(d.Synonyms))
+			for i, v := range d.Synonyms {
+				synonyms[i] = "`" + v + "`"
+			}
+			def := ""
+			if d.Default != "" {
+				def = "`" + markdownCell(d.Default) + "`"
+			}
+			required := ""
+			if d.Required {
+				required = "yes"
+			}
+			usage := append([]string{d.Usage}, d.Deprecated...)
+			buf.WriteString(fmt.Sprintf("| <a id=\"%s\"></a>[`%s`](#%s) | %s | %s | %s | %s | %s |\n",
+				d.Anchor, markdownCell(d.Name), d.Anchor, strings.Join(synonyms, ", "),
+				markdownCell(d.Type), def, required, markdownCell(strings.Join(usage, "\n"))))
+		}
+	}
+
+	if !isNoneInfo(f.details) {
+		buf.WriteString(fmt.Sprintf("\n## Details\n\n```\n%s\n```\n", strings.TrimRight(f.details, "\n")))
+	}
+	if !isNoneInfo(f.copyright) {
+		buf.WriteString(fmt.Sprintf("\n## CopyRight\n\n%s\n", strings.TrimRight(f.copyright, "\n")))
+	}
+	return buf.String()
+}
+
+//markdownCell escapes s to show in a Markdown table cell
+func markdownCell(s string) string {
+	s = strings.Replace(s, "|", `\|`, -1)
+	return strings.Replace(s, "\n", "<br>", -1)
+}
+
+//GetHTML returns the reference doc of command as a standalone HTML page
+func GetHTML() string {
+	return CommandLine.GetHTML()
+}
+
+//GetHTML returns the reference doc of command as a standalone HTML page.
+//Every flag has an anchor as "#flag-name", or "#arg-logicname" for no-name ones.
+func (f *FlagSet) GetHTML() string {
+	var buf bytes.Buffer
+	esc := html.EscapeString
+	buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	buf.WriteString(fmt.Sprintf("<title>%s</title>\n</head>\n<body>\n", esc(thisCmd)))
+	buf.WriteString(fmt.Sprintf("<h1>%s</h1>\n", esc(thisCmd)))
+	if !isNoneInfo(f.summary) {
+		buf.WriteString(fmt.Sprintf("<p>%s</p>\n", esc(strings.TrimSpace(f.summary))))
+	}
+
+	buf.WriteString("<h2>Usage</h2>\n<pre>")
+	buf.WriteString(esc(thisCmd))
+	for _, item := range synopsisItems(f.usageFlags(), EnglishCatalog[MsgGroupSynopsis]) {
+		buf.WriteString(" ")
+		buf.WriteString(esc(item))
+	}
+	buf.WriteString("</pre>\n")
+
+	for _, section := range f.docSections() {
+		if section.Title == "" {
+			buf.WriteString("<h2>Options</h2>\n")
+		} else {
+			buf.WriteString(fmt.Sprintf("<h3>%s options</h3>\n", esc(section.Title)))
+		}
+		buf.WriteString("<table>\n<tr><th>Flag</th><th>Synonyms</th><th>Type</th><th>Default</th><th>Required</th><th>Description</th></tr>\n")
+		for _, d := range section.Flags {
+			synonyms := make([]string, len(d.Synonyms))
+			for i, v := range d.Synonyms {
+				synonyms[i] = "<code>" + esc(v) + "</code>"
+			}
+			def := ""
+			if d.Default != "" {
+				def = "<code>" + esc(d.Default) + "</code>"
+			}
+			required := ""
+			if d.Required {
+				required = "yes"
+			}
+			usage := append([]string{d.Usage}, d.Deprecated...)
+			buf.WriteString(fmt.Sprintf("<tr id=\"%s\"><td><a href=\"#%s\"><code>%s</code></a></td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				esc(d.Anchor), esc(d.Anchor), esc(d.Name), strings.Join(synonyms, ", "),
+				esc(d.Type), def, required, strings.Replace(esc(strings.Join(usage, "\n")), "\n", "<br>", -1)))
+		}
+		buf.WriteString("</table>\n")
+	}
+
+	if !isNoneInfo(f.details) {
+		buf.WriteString(fmt.Sprintf("<h2>Details</h2>\n<pre>%s</pre>\n", esc(strings.TrimRight(f.details, "\n"))))
+	}
+	if !isNoneInfo(f.copyright) {
+		buf.WriteString(fmt.Sprintf("<h2>CopyRight</h2>\n<p>%s</p>\n", esc(strings.TrimRight(f.copyright, "\n"))))
+	}
+	buf.WriteString("</body>\n</html>\n")
+	return buf.String()
+}
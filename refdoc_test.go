@@ -0,0 +1,56 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func newRefDocFlagSet() *cmdline.FlagSet {
+	cmd := cmdline.NewFlagSet("refdoc", cmdline.ContinueOnError)
+	cmd.Summary("<thiscmd> is an example of cmdline package usage.")
+	cmd.Int("t", "ttl", 64, false, "time to live")
+	cmd.Alias("ttl", "t")
+	cmd.String("o", "format", "a|b", false, "output `format`")
+	cmd.String("", "host", "", true, "host ip or name")
+	cmd.Group("Output", "o")
+	return cmd
+}
+
+func TestGetMarkdown(t *testing.T) {
+	thisCmd := cmdline.ReplaceTags("<thiscmd>")
+	doc := newRefDocFlagSet().GetMarkdown()
+	for _, need := range []string{
+		"# " + thisCmd + "\n\n" + thisCmd + " is an example of cmdline package usage.\n",
+		"## Usage\n\n```\n" + thisCmd + " [-t|ttl=<ttl>] [output options] <host>\n```\n",
+		"| <a id=\"flag-t\"></a>[`-t\\|ttl=<ttl>`](#flag-t) | `-t`, `-ttl` | int | `64` |  | time to live |\n",
+		"| <a id=\"arg-host\"></a>[`<host>`](#arg-host) |  | string |  | yes | host ip or name |\n",
+		"### Output options\n\n",
+		"| <a id=\"flag-o\"></a>[`-o=<format>`](#flag-o) | `-o` | format | `a\\|b` |  | output format |\n",
+	} {
+		if !strings.Contains(doc, need) {
+			t.Errorf("GetMarkdown fail \nneed:\n%s\ngot:\n%s", need, doc)
+		}
+	}
+}
+
+func TestGetHTML(t *testing.T) {
+	doc := newRefDocFlagSet().GetHTML()
+	for _, need := range []string{
+		"<!DOCTYPE html>\n",
+		"<tr id=\"flag-t\"><td><a href=\"#flag-t\"><code>-t|ttl=&lt;ttl&gt;</code></a></td><td><code>-t</code>, <code>-ttl</code></td><td>int</td><td><code>64</code></td><td></td><td>time to live</td></tr>\n",
+		"<tr id=\"arg-host\"><td><a href=\"#arg-host\"><code>&lt;host&gt;</code></a></td><td></td><td>string</td><td></td><td>yes</td><td>host ip or name</td></tr>\n",
+		"<h3>Output options</h3>\n",
+		"</body>\n</html>\n",
+	} {
+		if !strings.Contains(doc, need) {
+			t.Errorf("GetHTML fail \nneed:\n%s\ngot:\n%s", need, doc)
+		}
+	}
+}
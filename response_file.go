@@ -0,0 +1,110 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	maxResponseFileDepth = 16 //max nesting depth of response files
+)
+
+//ResponseFiles enables expanding "@file" arguments when Parse, returns the old setting
+func ResponseFiles(enable bool) (old bool) {
+	return CommandLine.ResponseFiles(enable)
+}
+
+//ResponseFiles enables expanding "@file" arguments when Parse, returns the old setting.
+//An "@file" argument is replaced with the arguments read from file, which are
+//split from every line by SplitArgs. Lines start with '#' are comments.
+//The value of a flag, as "-p @file", and arguments after "--" are never expanded.
+//Response files can include other ones, up to 16 levels.
+func (f *FlagSet) ResponseFiles(enable bool) (old bool) {
+	old, f.respFiles = f.respFiles, enable
+	return
+}
+
+//expandResponseFiles replaces standalone "@file" ones of arguments with the arguments in file.
+//Values of flags, as "@file" of "-p @file", and arguments after the terminator "--" are kept.
+//sources are the sources of arguments, and the results returns with their own sources.
+//done reports whether the terminator is met.
+func (f *FlagSet) expandResponseFiles(arguments []string, sources []Source, depth int) (r []string, rs []Source, done bool, err error) {
+	for i, arg := range arguments {
+		if done || len(arg) < 2 || arg[0] != '@' || len(r) > 0 && f.needsValue(r[len(r)-1]) {
+			r = append(r, arg)
+			rs = append(rs, sources[i])
+			done = done || isFlagLead(arg)
+			continue
+		}
+		file := arg[1:]
+		if depth >= maxResponseFileDepth {
+			return nil, nil, false, fmt.Errorf("%s: nested more than %d levels", file, maxResponseFileDepth)
+		}
+		args, lines, err := readResponseFile(file)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		argSources := make([]Source, len(args))
+		for j := range args {
+			argSources[j] = Source{Origin: OriginFile, Arg: sources[i].Arg, File: file, Line: lines[j]}
+		}
+		args, argSources, done, err = f.expandResponseFiles(args, argSources, depth+1)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		r = append(r, args...)
+		rs = append(rs, argSources...)
+	}
+	return r, rs, done, nil
+}
+
+//needsValue reports whether arg is a non-bool flag that takes the next argument as its value
+func (f *FlagSet) needsValue(arg string) bool {
+	s, isString := detectString(arg)
+	if isString || s == "" || !isFlagLeadByte(s[0]) || isFlagLead(s) {
+		return false
+	}
+	name := s[1:]
+	if s[0] == '-' {
+		name = strings.TrimPrefix(name, "-")
+	}
+	value := ""
+	if i := strings.IndexByte(name, '='); i >= 0 {
+		name, value = name[:i], name[i+1:]
+	}
+	flag, ok := f.formal[name]
+	if !ok || value != "" && value != "=" {
+		return false
+	}
+	fv, ok := flag.Value.(boolFlag)
+	return !ok || !fv.IsBoolFlag()
+}
+
+//readResponseFile returns arguments in response file and the lines they are in
+func readResponseFile(file string) (args []string, lines []int, err error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		words, err := SplitArgs(line)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %w", file, i+1, err)
+		}
+		for _, arg := range words {
+			args = append(args, arg)
+			lines = append(lines, i+1)
+		}
+	}
+	return args, lines, nil
+}
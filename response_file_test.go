@@ -0,0 +1,88 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestResponseFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmdline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	inner := filepath.Join(dir, "inner.txt")
+	outer := filepath.Join(dir, "outer.txt")
+	loop := filepath.Join(dir, "loop.txt")
+	bad := filepath.Join(dir, "bad.txt")
+	ioutil.WriteFile(inner, []byte("-c=4\n"), 0644)
+	ioutil.WriteFile(outer, []byte("# ping options\n-t=20  @"+inner+"\n\n\"hello world\"\n"), 0644)
+	ioutil.WriteFile(loop, []byte("@"+loop), 0644)
+	ioutil.WriteFile(bad, []byte("-t=1\n-c 'unbalanced\n"), 0644)
+
+	var (
+		ttl, count     int
+		host, password string
+	)
+	cmd := cmdline.NewFlagSet("response", cmdline.ContinueOnError)
+	cmd.DisableUsage(true)
+	cmd.IntVar(&ttl, "t", "ttl", 0, false, "ttl")
+	cmd.IntVar(&count, "c", "count", 0, false, "count")
+	cmd.StringVar(&host, "", "host", "", false, "host")
+	cmd.StringVar(&password, "p", "password", "", false, "password")
+	cmd.Secret("p")
+
+	if err := cmd.Parse([]string{"@" + outer}); err != nil {
+		t.Fatal(err)
+	}
+	if host != "@"+outer {
+		t.Errorf("response files expanded without enabled: %q", host)
+	}
+
+	cmd.ResponseFiles(true)
+	host = ""
+	if err := cmd.Parse([]string{"@" + outer, "-c=5"}); err != nil {
+		t.Fatal(err)
+	}
+	if ttl != 20 || count != 5 || host != "hello world" {
+		t.Errorf("response files fail: ttl=%d count=%d host=%q", ttl, count, host)
+	}
+
+	if err := cmd.Parse([]string{"@" + loop}); err == nil || !strings.Contains(err.Error(), "nested more than") {
+		t.Errorf("nested response files need error, got %v", err)
+	}
+	ttl, count, host = 0, 0, ""
+	if err := cmd.Parse([]string{"-c=1", "--", "@" + outer}); err != nil {
+		t.Fatal(err)
+	}
+	if args := cmd.Args(); ttl != 0 || count != 1 || len(args) != 1 || args[0] != "@"+outer {
+		t.Errorf("response file expanded after --: ttl=%d count=%d args=%q", ttl, count, args)
+	}
+	secret := filepath.Join(dir, "secret.txt")
+	ioutil.WriteFile(secret, []byte("-c=9\n"), 0600)
+	count = 0
+	if err := cmd.Parse([]string{"-p", "@" + secret}); err != nil {
+		t.Fatal(err)
+	}
+	if password != "-c=9" || count != 0 {
+		t.Errorf("value of -p expanded as response file: password=%q count=%d", password, count)
+	}
+
+	need := bad + ":2: syntax error at position 3: unterminated single quote"
+	if err := cmd.Parse([]string{"@" + bad}); err == nil || !strings.Contains(err.Error(), need) {
+		t.Errorf("unbalanced quote in response file need error %q, got %v", need, err)
+	}
+	if err := cmd.Parse([]string{"@" + filepath.Join(dir, "missing.txt")}); err == nil {
+		t.Error("missing response file need error")
+	}
+}
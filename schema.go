@@ -0,0 +1,104 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"strings"
+)
+
+//CommandSchema is a serializable description of a command line interface
+type CommandSchema struct {
+	Command     string       `json:"command"`
+	AppName     string       `json:"appName,omitempty"`
+	Version     string       `json:"version,omitempty"`
+	VersionTime string       `json:"versionTime,omitempty"`
+	VersionTag  string       `json:"versionTag,omitempty"`
+	Summary     string       `json:"summary,omitempty"`
+	Details     string       `json:"details,omitempty"`
+	CopyRight   string       `json:"copyright,omitempty"`
+	Groups      []string     `json:"groups,omitempty"`
+	Flags       []FlagSchema `json:"flags"`
+}
+
+//FlagSchema is a serializable description of a flag
+type FlagSchema struct {
+	Synonyms   []string          `json:"synonyms"` //empty for no-name flags
+	LogicName  string            `json:"logicName"`
+	Required   bool              `json:"required"`
+	Type       string            `json:"type"`
+	DefValue   string            `json:"defValue"`
+	Min        string            `json:"min,omitempty"` //lower bound of ranged flags
+	Max        string            `json:"max,omitempty"` //upper bound of ranged flags
+	Usage      string            `json:"usage"`
+	Group      string            `json:"group,omitempty"`
+	Secret     bool              `json:"secret,omitempty"`
+	Hidden     []string          `json:"hidden,omitempty"`     //hidden synonyms
+	Deprecated map[string]string `json:"deprecated,omitempty"` //deprecated synonyms and their notes
+}
+
+//noneInfo returns s, or empty if s is not assigned
+func noneInfo(s string) string {
+	if isNoneInfo(s) {
+		return ""
+	}
+	return s
+}
+
+//Schema returns the description of command line flags
+func Schema() *CommandSchema {
+	return CommandLine.Schema()
+}
+
+//Schema returns the description of the command and all its flags,
+//including hidden ones. It can be serialized by encoding/json.
+func (f *FlagSet) Schema() *CommandSchema {
+	s := &CommandSchema{
+		Command:     thisCmd,
+		AppName:     noneInfo(f.GetAppName()),
+		Version:     noneInfo(f.GetVersion()),
+		VersionTime: noneInfo(f.GetVersionTime()),
+		VersionTag:  noneInfo(f.GetVersionTag()),
+		Summary:     noneInfo(f.GetSummary()),
+		Details:     noneInfo(f.GetDetails()),
+		CopyRight:   noneInfo(f.GetCopyRight()),
+		Groups:      f.groups,
+		Flags:       []FlagSchema{},
+	}
+	f.VisitAll(func(flag *Flag) {
+		if flag.Visitor != flag.Name { //Synonyms show at the first one only
+			return
+		}
+		fs := FlagSchema{
+			Synonyms:  []string{},
+			LogicName: flag.LogicName,
+			Required:  flag.Required,
+			DefValue:  flag.mask(flag.DefValue),
+			Group:     flag.Group,
+			Secret:    flag.Secret,
+		}
+		fs.Type, fs.Usage = UnquoteUsage(flag)
+		fs.Min, fs.Max, _ = flag.Range()
+		if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() && fs.Type == "" {
+			fs.Type = "bool"
+		}
+		if !strings.HasPrefix(flag.Name, gNoNamePrefix) {
+			fs.Synonyms = append(fs.Synonyms, flag.Synonyms...)
+		}
+		for _, v := range fs.Synonyms {
+			if flag.hidden[v] {
+				fs.Hidden = append(fs.Hidden, v)
+			}
+			if note := flag.Deprecation(v); note != "" {
+				if fs.Deprecated == nil {
+					fs.Deprecated = make(map[string]string)
+				}
+				fs.Deprecated[v] = note
+			}
+		}
+		s.Flags = append(s.Flags, fs)
+	})
+	return s
+}
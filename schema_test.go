@@ -0,0 +1,80 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestSchema(t *testing.T) {
+	var sCheck = `{
+  "command": "<thiscmd>",
+  "appName": "example",
+  "version": "1.0.2",
+  "summary": "<thiscmd> is an example of cmdline package usage.",
+  "flags": [
+    {
+      "synonyms": [
+        "4"
+      ],
+      "logicName": "v4",
+      "required": false,
+      "type": "bool",
+      "defValue": "false",
+      "usage": "ipv4"
+    },
+    {
+      "synonyms": [
+        "t",
+        "ttl",
+        "hops"
+      ],
+      "logicName": "ttl",
+      "required": false,
+      "type": "hops",
+      "defValue": "64",
+      "usage": "max hops to live",
+      "hidden": [
+        "hops"
+      ],
+      "deprecated": {
+        "ttl": "use -t"
+      }
+    },
+    {
+      "synonyms": [],
+      "logicName": "host",
+      "required": true,
+      "type": "string",
+      "defValue": "",
+      "usage": "host ip or name"
+    }
+  ]
+}`
+	sCheck = cmdline.ReplaceTags(sCheck)
+	cmd := cmdline.NewFlagSet("schema", cmdline.ContinueOnError)
+	cmd.AppName("example")
+	cmd.Version("1.0.2")
+	cmd.Summary("<thiscmd> is an example of cmdline package usage.")
+	cmd.Bool("4", "v4", false, false, "ipv4")
+	cmd.Int("t", "ttl", 64, false, "max `hops` to live")
+	cmd.Alias("ttl", "t")
+	cmd.Alias("hops", "t")
+	cmd.Hidden("hops")
+	cmd.Deprecated("ttl", "use -t")
+	cmd.String("", "host", "", true, "host ip or name")
+
+	b, err := json.MarshalIndent(cmd.Schema(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != sCheck {
+		t.Errorf("Schema fail \nneed:\n%s\ngot:\n%s", sCheck, b)
+	}
+}
@@ -0,0 +1,90 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+//secretMask is shown instead of values of secret flags
+const secretMask = "******"
+
+//Secret marks command line flags as secret
+func Secret(names ...string) {
+	CommandLine.Secret(names...)
+}
+
+//Secret marks flags as secret. Values and defaults of secret flags are masked
+//in usage page, error messages, schema and dumps.
+//To keep secrets out of process listings, a secret flag can take its value
+//from a file as "-password=@/run/secrets/pw", or from an environment variable
+//as "-password=env:DB_PASSWORD". Values typed at prompt are taken as they are.
+func (f *FlagSet) Secret(names ...string) {
+	for _, name := range names {
+		f.mustLookup("Secret", name).Secret = true
+	}
+}
+
+//mask returns s, or secretMask if the flag is secret
+func (f *Flag) mask(s string) string {
+	if f.Secret && s != "" {
+		return secretMask
+	}
+	return s
+}
+
+//ShowValue returns the current value of flag as text, masked if the flag is secret
+func (f *Flag) ShowValue() string {
+	return f.mask(f.Value.String())
+}
+
+//showDefault reports whether the default value shows in usage page
+func (f *Flag) showDefault() bool {
+	return !f.Secret && !isZeroValue(f, f.DefValue)
+}
+
+//readSecret returns value of secret flag and its source from "@file" or "env:NAME",
+//but not for the typed ones of prompt
+func readSecret(flag *Flag, value string, src Source) (string, Source, error) {
+	switch {
+	case !flag.Secret || src.Origin == OriginPrompt:
+		return value, src, nil
+	case strings.HasPrefix(value, "@"):
+		b, err := ioutil.ReadFile(ExpandPath(value[1:]))
+		if err != nil {
+			return "", src, err
+		}
+		src.Origin, src.File, src.Line = OriginFile, value[1:], 0
+		return strings.TrimRight(string(b), "\r\n"), src, nil
+	case strings.HasPrefix(value, "env:"):
+		v, ok := os.LookupEnv(value[len("env:"):])
+		if !ok {
+			return "", src, fmt.Errorf("environment variable %s is not set", value[len("env:"):])
+		}
+		src.Origin, src.Env = OriginEnv, value[len("env:"):]
+		return v, src, nil
+	}
+	return value, src, nil
+}
+
+//errSecretValue replaces errors of setting secret flags
+var errSecretValue = errors.New("invalid value " + secretMask)
+
+//maskError hides err of setting secret flag by a fixed one, as err may show
+//the value in any form, as quoted by %q
+func maskError(flag *Flag, err error) error {
+	if !flag.Secret {
+		return err
+	}
+	if _, ok := err.(*hookError); ok {
+		return &hookError{errSecretValue}
+	}
+	return errSecretValue
+}
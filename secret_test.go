@@ -0,0 +1,85 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestSecret(t *testing.T) {
+	var out bytes.Buffer
+	cmd := cmdline.NewFlagSet("secret", cmdline.ContinueOnError)
+	cmd.SetOutput(&out)
+	cmd.UsageWidth(80)
+	password := cmd.String("p", "password", "default-pw", false, "password")
+	cmd.Int("pin", "pin", 1234, false, "pin")
+	cmd.Validate("pin", cmdline.IntRange(0, 9999))
+	cmd.Secret("p", "pin")
+
+	file := filepath.Join(t.TempDir(), "pw")
+	if err := ioutil.WriteFile(file, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Parse([]string{"-p=@" + file}); err != nil || *password != "from-file" {
+		t.Errorf("Parse(@file) = %v, password=%q", err, *password)
+	}
+	t.Setenv("CMDLINE_TEST_PW", "from-env")
+	if err := cmd.Set("p", "env:CMDLINE_TEST_PW"); err != nil || *password != "from-env" {
+		t.Errorf("Set(env:) = %v, password=%q", err, *password)
+	}
+	if err := cmd.Set("p", "env:CMDLINE_TEST_NO_SUCH_ENV"); err == nil {
+		t.Error("Set(env:) of missing environment variable need error")
+	}
+
+	out.Reset()
+	for _, arg := range []string{"-pin=98x76", "-pin=98765"} {
+		err := cmd.Parse([]string{arg})
+		if err == nil {
+			t.Fatalf("Parse(%s) need error", arg)
+		}
+		for _, s := range []string{err.Error(), out.String()} {
+			if strings.Contains(s, "987") {
+				t.Errorf("secret shows in error: %s", s)
+			}
+		}
+	}
+
+	cmd.Validate("p", func(value string) error {
+		if strings.ContainsAny(value, `"\`) {
+			return fmt.Errorf("password %q has quotes or backslashes", value)
+		}
+		return nil
+	})
+	for _, c := range [][2]string{{"p", `pw"1`}, {"p", `pw\2`}, {"pin", "x"}} {
+		if err := cmd.Set(c[0], c[1]); err == nil || !strings.HasSuffix(err.Error(), "invalid value ******") {
+			t.Errorf("Set(%s, %s) error = %v", c[0], c[1], err)
+		}
+	}
+	out.Reset()
+	if err := cmd.Parse([]string{`-p=a"b\c`}); err == nil || strings.Contains(err.Error(), "b\\") || strings.Contains(out.String(), "b\\") {
+		t.Errorf("Parse(-p) = %v, output:\n%s", err, out.String())
+	}
+
+	usage := cmd.GetUsage()
+	if strings.Contains(usage, "default-pw") || strings.Contains(usage, "1234") {
+		t.Errorf("secret default shows in usage:\n%s", usage)
+	}
+	b, _ := json.Marshal(cmd.Schema())
+	if strings.Contains(string(b), "default-pw") || !strings.Contains(string(b), `"secret":true`) {
+		t.Errorf("schema = %s", b)
+	}
+	if v := cmd.Lookup("p").ShowValue(); v != "******" {
+		t.Errorf("ShowValue() = %s", v)
+	}
+}
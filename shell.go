@@ -0,0 +1,261 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//ErrExit is returned by Shell.Exec when an "exit" or "quit" line is executed
+var ErrExit = errors.New("shell: exit")
+
+//shellCommand is a command handled by Shell
+type shellCommand struct {
+	flags *FlagSet
+	run   func(flags *FlagSet) error
+}
+
+//Shell is an interactive console. It reads lines, splits them by SplitArgs,
+//and parses them with the FlagSet of the command they call.
+//Lines read from a terminal are edited with completion of commands and
+//flags by Tab, and history by up and down arrows.
+//
+//Built-in commands are:
+//    help [command]  show commands or usage page of command
+//    history         show history lines
+//    !!              execute the last line
+//    !n              execute the n'th history line
+//    exit|quit       leave the console
+type Shell struct {
+	Prompt string    //prompt of every line
+	In     io.Reader //source of lines, nil means os.Stdin
+	Out    io.Writer //destination of prompts and messages, nil means os.Stdout
+
+	commands map[string]*shellCommand
+	names    []string //names of commands in declared order
+	history  []string
+}
+
+//NewShell returns a Shell with prompt
+func NewShell(prompt string) *Shell {
+	return &Shell{Prompt: prompt}
+}
+
+//Handle let command name be parsed by flags and then executed by run.
+//A command with empty name handles all lines that do not call any other command.
+//Flags are parsed with ContinueOnError so that bad lines never end the console.
+func (s *Shell) Handle(name string, flags *FlagSet, run func(flags *FlagSet) error) {
+	if s.commands == nil {
+		s.commands = make(map[string]*shellCommand)
+	}
+	if _, ok := s.commands[name]; !ok {
+		s.names = append(s.names, name)
+	}
+	s.commands[name] = &shellCommand{flags: flags, run: run}
+}
+
+//History returns the lines executed
+func (s *Shell) History() []string {
+	return s.history
+}
+
+func (s *Shell) output() io.Writer {
+	if s.Out == nil {
+		return os.Stdout
+	}
+	return s.Out
+}
+
+//Run reads and executes lines until end of input or an "exit" line
+func (s *Shell) Run() error {
+	in, edit := s.In, false
+	if in == nil {
+		in, edit = os.Stdin, isTerminal(os.Stdin.Fd())
+	}
+	reader := bufio.NewReader(in)
+	for {
+		line, err := s.readLine(reader, edit)
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if printed, e := s.exec(line); e == ErrExit {
+			return nil
+		} else if e != nil && e != ErrHelp && !printed {
+			fmt.Fprintln(s.output(), e)
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+//readLine reads a line after prompt, it is edited in raw mode if edit
+func (s *Shell) readLine(in *bufio.Reader, edit bool) (string, error) {
+	if edit {
+		if restore, err := makeRaw(os.Stdin.Fd()); err == nil {
+			defer restore()
+			return s.editLine(in, s.output())
+		}
+	}
+	fmt.Fprint(s.output(), s.Prompt)
+	line, err := in.ReadString('\n')
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+//Exec executes a line
+func (s *Shell) Exec(line string) error {
+	_, err := s.exec(line)
+	return err
+}
+
+//exec executes a line, printed reports whether the error has been printed by FlagSet
+func (s *Shell) exec(line string) (printed bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return false, nil
+	}
+	if line[0] == '!' { //history expansion
+		n := len(s.history)
+		if line != "!!" {
+			var err error
+			if n, err = strconv.Atoi(line[1:]); err != nil || n < 1 || n > len(s.history) {
+				return false, fmt.Errorf("%s: event not found", line)
+			}
+		}
+		if n == 0 {
+			return false, fmt.Errorf("%s: event not found", line)
+		}
+		line = s.history[n-1]
+		fmt.Fprintln(s.output(), line)
+	}
+	s.history = append(s.history, line)
+
+	args, err := SplitArgs(line)
+	if err != nil {
+		return false, err
+	}
+	switch args[0] {
+	case "exit", "quit":
+		return false, ErrExit
+	case "history":
+		for i, v := range s.history {
+			fmt.Fprintf(s.output(), "%5d  %s\n", i+1, v)
+		}
+		return false, nil
+	case "help":
+		return false, s.help(args[1:])
+	}
+
+	cmd, ok := s.commands[args[0]]
+	if ok && args[0] != "" {
+		args = args[1:]
+	} else if cmd, ok = s.commands[""]; !ok {
+		return false, fmt.Errorf("%s: command not found", args[0])
+	}
+	cmd.flags.Reset()
+	old := cmd.flags.errorHandling
+	cmd.flags.errorHandling = ContinueOnError
+	err = cmd.flags.Parse(args)
+	cmd.flags.errorHandling = old
+	if err != nil {
+		return !cmd.flags.disableUsage, err
+	}
+	if cmd.run == nil {
+		return false, nil
+	}
+	return false, cmd.run(cmd.flags)
+}
+
+//help shows names of commands, or usage page of command args[0]
+func (s *Shell) help(args []string) error {
+	if len(args) > 0 {
+		cmd, ok := s.commands[args[0]]
+		if !ok {
+			return fmt.Errorf("%s: command not found", args[0])
+		}
+		fmt.Fprint(s.output(), cmd.flags.GetUsage())
+		return nil
+	}
+	if cmd, ok := s.commands[""]; ok && len(s.names) == 1 {
+		fmt.Fprint(s.output(), cmd.flags.GetUsage())
+		return nil
+	}
+	names := make([]string, 0, len(s.names))
+	for _, name := range s.names {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	fmt.Fprintf(s.output(), "commands: %s\n", strings.Join(names, " "))
+	fmt.Fprintln(s.output(), "built-in: help [command], history, !!, !n, exit, quit")
+	return nil
+}
+
+//Complete returns candidates to complete the last word of line.
+//They are names of commands for the first word, or flags of the command otherwise.
+//Hidden flags are never completed.
+func (s *Shell) Complete(line string) []string {
+	args := SplitLine(line)
+	word := ""
+	if len(args) > 0 && !strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\t") {
+		word, args = args[len(args)-1], args[:len(args)-1]
+	}
+
+	var candidates []string
+	if len(args) == 0 {
+		for _, name := range s.names {
+			if name != "" {
+				candidates = append(candidates, name)
+			}
+		}
+		candidates = append(candidates, "exit", "help", "history", "quit")
+	}
+	cmd, ok := (*shellCommand)(nil), false
+	if len(args) > 0 {
+		cmd, ok = s.commands[args[0]]
+	}
+	if !ok {
+		cmd, ok = s.commands[""]
+	}
+	if ok && (len(args) > 0 || strings.HasPrefix(word, "-") || len(s.names) == 1) {
+		candidates = append(candidates, cmd.flags.completeFlags()...)
+	}
+
+	var r []string
+	for _, v := range candidates {
+		if strings.HasPrefix(v, word) {
+			r = append(r, v)
+		}
+	}
+	sort.Strings(r)
+	return r
+}
+
+//completeFlags returns visible flag names, non-bool ones end with '='
+func (f *FlagSet) completeFlags() []string {
+	var r []string
+	f.VisitAll(func(flag *Flag) {
+		if strings.HasPrefix(flag.Visitor, gNoNamePrefix) || flag.hidden[flag.Visitor] {
+			return
+		}
+		name := "-" + flag.Visitor
+		if fv, ok := flag.Value.(boolFlag); !ok || !fv.IsBoolFlag() {
+			name += "="
+		}
+		r = append(r, name)
+	})
+	return r
+}
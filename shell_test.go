@@ -0,0 +1,105 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestShell(t *testing.T) {
+	flags := cmdline.NewFlagSet("ping", cmdline.ExitOnError)
+	flags.SetOutput(new(bytes.Buffer))
+	n := flags.Int("n", "count", 4, false, "count of requests")
+	v := flags.Bool("v", "verbose", false, false, "verbose output")
+	var out bytes.Buffer
+	var got []string
+	sh := cmdline.NewShell("> ")
+	sh.In = strings.NewReader("ping -n=2 -v\n\nping\nfoo\nping -n=x\n!1\nhistory\nexit\nping -n=9\n")
+	sh.Out = &out
+	sh.Handle("ping", flags, func(flags *cmdline.FlagSet) error {
+		got = append(got, fmt.Sprintf("n=%d v=%v", *n, *v))
+		return nil
+	})
+	if err := sh.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"n=2 v=true", "n=4 v=false", "n=2 v=true"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("runs = %q, want %q", got, want)
+	}
+	if !strings.Contains(out.String(), "foo: command not found") {
+		t.Errorf("missing unknown command error in %q", out.String())
+	}
+	bad := `invalid value "x" for flag -n`
+	if n := strings.Count(flags.Output().(*bytes.Buffer).String(), bad); n != 1 || strings.Contains(out.String(), bad) {
+		t.Errorf("invalid value error need to be printed once by flags, got %d times, and in %q", n, out.String())
+	}
+	if h := flags.ErrorHandling(); h != cmdline.ExitOnError {
+		t.Errorf("ErrorHandling() = %v after Run, want ExitOnError", h)
+	}
+	want := []string{"ping -n=2 -v", "ping", "foo", "ping -n=x", "ping -n=2 -v", "history", "exit"}
+	if h := sh.History(); !reflect.DeepEqual(h, want) {
+		t.Errorf("History() = %q, want %q", h, want)
+	}
+}
+
+func TestShellDefaultCommand(t *testing.T) {
+	flags := cmdline.NewFlagSet("ping", cmdline.ContinueOnError)
+	flags.SetOutput(new(bytes.Buffer))
+	n := flags.Int("n", "count", 4, false, "count of requests")
+	v := flags.Bool("v", "verbose", false, false, "verbose output")
+	sh := cmdline.NewShell("")
+	sh.Out = new(bytes.Buffer)
+	sh.Handle("", flags, nil)
+	if err := sh.Exec("-n=7 -v"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sh.Exec("-v"); err != nil {
+		t.Fatal(err)
+	}
+	if *n != 4 || !*v {
+		t.Errorf("n=%d v=%v, want n=4 v=true", *n, *v)
+	}
+	if err := sh.Exec(`-n "7`); err == nil {
+		t.Error("Exec of unbalanced quote need error")
+	} else if _, ok := err.(*cmdline.SyntaxError); !ok {
+		t.Errorf("Exec of unbalanced quote error = %T %v, want *SyntaxError", err, err)
+	}
+	if err := sh.Exec("quit"); err != cmdline.ErrExit {
+		t.Errorf("Exec(quit) = %v, want ErrExit", err)
+	}
+}
+
+func TestShellComplete(t *testing.T) {
+	flags := cmdline.NewFlagSet("ping", cmdline.ContinueOnError)
+	flags.Int("n", "count", 4, false, "count of requests")
+	flags.Bool("v", "verbose", false, false, "verbose output")
+	flags.String("secret", "secret", "", false, "hidden flag")
+	flags.Hidden("secret")
+	sh := cmdline.NewShell("")
+	sh.Handle("ping", flags, nil)
+	sh.Handle("trace", cmdline.NewFlagSet("trace", cmdline.ContinueOnError), nil)
+	for _, c := range []struct {
+		line string
+		want []string
+	}{
+		{"", []string{"exit", "help", "history", "ping", "quit", "trace"}},
+		{"h", []string{"help", "history"}},
+		{"ping ", []string{"-n=", "-v"}},
+		{"ping -v -", []string{"-n=", "-v"}},
+		{"ping -s", nil},
+		{"trace -", nil},
+	} {
+		if got := sh.Complete(c.line); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Complete(%q) = %q, want %q", c.line, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"fmt"
+)
+
+//Source is where and how the value of a flag is set
+type Source struct {
+	Origin Origin //kind of the source
+	Name   string //synonym that sets the value, empty for default value
+	Arg    int    //1-based position of the argument in Parse arguments, 0 if not from arguments
+	File   string //response file, or file of a secret flag
+	Line   int    //line in response file, 0 if unknown
+	Env    string //environment variable of a secret flag
+}
+
+func (s Source) String() string {
+	switch {
+	case s.Env != "":
+		return "env " + s.Env
+	case s.File != "" && s.Line > 0:
+		return fmt.Sprintf("%s:%d", s.File, s.Line)
+	case s.File != "":
+		return s.File
+	case s.Arg > 0:
+		return fmt.Sprintf("argument %d", s.Arg)
+	}
+	return s.Origin.String()
+}
+
+//Source returns where and how the value of flag is set at the last time
+func (f *Flag) Source() Source {
+	if f.mu != nil {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	return f.source
+}
+
+//SetCount returns how many times the value of flag is set since the last Reset
+func (f *Flag) SetCount() int {
+	if f.mu != nil {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	return f.count
+}
+
+//cumulativeFlag is a Value that accumulates values of repeated flags, which are not duplicates
+type cumulativeFlag interface {
+	Value
+	IsCumulative() bool
+}
+
+//RejectDuplicates let Parse fail if a command line flag is set more than once, returns the old setting
+func RejectDuplicates(enable bool) (old bool) {
+	return CommandLine.RejectDuplicates(enable)
+}
+
+//RejectDuplicates let Parse fail if a flag is set more than once by its arguments,
+//even by different synonyms, as "-t=20 ... -ttl=5". Returns the old setting.
+//Values that have method "IsCumulative() bool" returns true are allowed to repeat.
+func (f *FlagSet) RejectDuplicates(enable bool) (old bool) {
+	old, f.noDups = f.noDups, enable
+	return
+}
+
+//checkDuplicate returns error if flag is set again by name from src in the current Parse
+//while duplicates are rejected, values of the last Parse and Set are not duplicates
+func (f *FlagSet) checkDuplicate(flag *Flag, name string, src Source) error {
+	if !f.noDups {
+		return nil
+	}
+	first, seen := f.argSet[flag]
+	if !seen {
+		if f.argSet == nil {
+			f.argSet = make(map[*Flag]Source)
+		}
+		src.Name = name
+		f.argSet[flag] = src
+		return nil
+	}
+	if fv, ok := flag.Value.(cumulativeFlag); ok && fv.IsCumulative() {
+		return nil
+	}
+	return f.failf(f.msg(MsgDuplicateFlag), name, src, first.Name, first)
+}
+
+//argSource returns the source of the next argument to parse
+func (f *FlagSet) argSource() Source {
+	if i := len(f.argSources) - len(f.args); i >= 0 && i < len(f.argSources) {
+		return f.argSources[i]
+	}
+	return Source{Origin: OriginCommandLine}
+}
+
+//commandLineSources returns sources of arguments from command line
+func commandLineSources(arguments []string) []Source {
+	r := make([]Source, len(arguments))
+	for i := range arguments {
+		r[i] = Source{Origin: OriginCommandLine, Arg: i + 1}
+	}
+	return r
+}
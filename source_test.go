@@ -0,0 +1,119 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestFlagSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "args.txt")
+	if err := ioutil.WriteFile(file, []byte("# options\n-v\n\n-ttl=5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CMDLINE_SOURCE_PW", "secret")
+
+	cmd := cmdline.NewFlagSet("source", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.ResponseFiles(true)
+	ttl := cmd.Int("t", "ttl", 64, false, "ttl")
+	cmd.Alias("ttl", "t")
+	cmd.Bool("v", "verbose", false, false, "verbose")
+	cmd.String("p", "password", "", false, "password")
+	cmd.Secret("p")
+	if err := cmd.Parse([]string{"-t=20", "-p=env:CMDLINE_SOURCE_PW", "@" + file}); err != nil {
+		t.Fatal(err)
+	}
+	if *ttl != 5 {
+		t.Errorf("ttl = %d, need 5", *ttl)
+	}
+
+	flag := cmd.Lookup("t")
+	need := cmdline.Source{Origin: cmdline.OriginFile, Name: "ttl", Arg: 3, File: file, Line: 4}
+	if src := flag.Source(); src != need {
+		t.Errorf("source of -t = %+v, need %+v", src, need)
+	}
+	if n := flag.SetCount(); n != 2 {
+		t.Errorf("set count of -t = %d, need 2", n)
+	}
+	if s := flag.Source().String(); s != file+":4" {
+		t.Errorf("source string = %q, need %q", s, file+":4")
+	}
+	need = cmdline.Source{Origin: cmdline.OriginFile, Name: "v", Arg: 3, File: file, Line: 2}
+	if src := cmd.Lookup("v").Source(); src != need {
+		t.Errorf("source of -v = %+v, need %+v", src, need)
+	}
+	need = cmdline.Source{Origin: cmdline.OriginEnv, Name: "p", Arg: 2, Env: "CMDLINE_SOURCE_PW"}
+	if src := cmd.Lookup("p").Source(); src != need {
+		t.Errorf("source of -p = %+v, need %+v", src, need)
+	}
+
+	cmd.Set("ttl", "7")
+	need = cmdline.Source{Origin: cmdline.OriginSet, Name: "ttl"}
+	if src := flag.Source(); src != need || flag.SetCount() != 3 {
+		t.Errorf("source of -t after Set = %+v count %d, need %+v count 3", src, flag.SetCount(), need)
+	}
+
+	cmd.Reset()
+	if src := flag.Source(); src != (cmdline.Source{}) || flag.SetCount() != 0 {
+		t.Errorf("source of -t after Reset = %+v count %d, need zero", src, flag.SetCount())
+	}
+	if s := flag.Source().String(); s != "default" {
+		t.Errorf("source string after Reset = %q, need default", s)
+	}
+}
+
+func TestRejectDuplicates(t *testing.T) {
+	cmd := cmdline.NewFlagSet("source", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.Int("t", "ttl", 64, false, "ttl")
+	cmd.Alias("ttl", "t")
+	cmd.Bool("v", "verbose", false, false, "verbose")
+	if err := cmd.Parse([]string{"-t=20", "-v", "-ttl=5"}); err != nil {
+		t.Fatalf("duplicates are accepted by default: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"-t=20", "-v", "-ttl=5"},
+		{"-v", "-verbose"},
+	} {
+		cmd.Reset()
+		cmd.RejectDuplicates(true)
+		err := cmd.Parse(args)
+		if err == nil {
+			t.Errorf("Parse(%q) accepts duplicates", args)
+			continue
+		}
+		if args[0] == "-t=20" {
+			need := "flag -ttl at argument 3 is already set by -t at argument 1"
+			if err.Error() != need {
+				t.Errorf("error = %q, need %q", err, need)
+			}
+		}
+	}
+
+	cmd.Reset()
+	cmd.Set("t", "1")
+	if err := cmd.Parse([]string{"-t=2", "-v"}); err != nil {
+		t.Errorf("value from Set is taken as duplicate: %v", err)
+	}
+	if s := cmd.Lookup("t").Source().String(); s != "argument 1" {
+		t.Errorf("source of -t = %q, need argument 1", s)
+	}
+	if err := cmd.Parse([]string{"-t=3", "-v"}); err != nil {
+		t.Errorf("value from the last Parse is taken as duplicate: %v", err)
+	}
+	if err := cmd.Parse([]string{"-v", "-t=4", "-ttl=5"}); err == nil {
+		t.Errorf("duplicates in the second Parse are accepted")
+	} else if need := "flag -ttl at argument 3 is already set by -t at argument 2"; err.Error() != need {
+		t.Errorf("error = %q, need %q", err, need)
+	}
+}
@@ -6,7 +6,10 @@
 package cmdline
 
 import (
+	"bytes"
+	"fmt"
 	"regexp"
+	"strings"
 )
 
 var ( //new line with any \t
@@ -14,49 +17,210 @@ var ( //new line with any \t
 )
 
 func isSpace(c byte) bool {
-	return (c == ' ' || c == '\t')
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
 }
 
-//SplitLine splits a command-line text separated with any ' ' or '\t'
+//SplitLine splits a command-line text as SplitArgs does, and removes quotes and
+//backslashes the same way. It never fails: an unbalanced quote runs to the end
+//of s and a trailing backslash is literal, which suits incomplete lines.
+//Use SplitArgs to get the *SyntaxError of them.
+//It breaks the compatibility with SplitLine of earlier versions, that keeps quotes in words:
+//a quoted word, as "--help", is taken as a flag by Parse now, quote it twice, as '"--help"',
+//to keep it a value.
 func SplitLine(s string) []string {
-	n := (len(s) + 1) / 2
-	lenSep := 1
-	start := 0
-	a := make([]string, n)
-	na := 0
-	inString := 0
-	escape := 0
-	lastQuot := byte(0)
-	for i := 0; i+lenSep <= len(s) && na+1 < n; i++ {
-		// consider " xxx 'yyy' zzz" as a single string
-		// " xxxx yyyy " case, do not include \"
-		if (s[i] == '\'' || s[i] == '"') && (inString%2 == 0 || lastQuot == s[i]) {
-			inString++
-			escape = 0
-			lastQuot = s[i]
-		} else {
-			if !isSpace(s[i]) {
-				escape = 0
+	a, _ := splitArgs(s, false)
+	return a
+}
+
+//SyntaxError reports a command-line text that can not be split
+type SyntaxError struct {
+	Line string //the text
+	Pos  int    //byte offset of the error in Line
+	Msg  string //description of the error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+//SplitArgs splits a command-line text as POSIX shell does, but without any expansion.
+//Words are separated by blanks; a backslash keeps the next character literal,
+//and removes itself with the next newline; nothing is special in single quotes;
+//backslash only escapes '$', '`', '"', '\\' and newline in double quotes.
+//A *SyntaxError is returned for unbalanced quotes and trailing backslash.
+func SplitArgs(s string) ([]string, error) {
+	return splitArgs(s, true)
+}
+
+//splitArgs splits s as SplitArgs, errors are ignored if not strict
+func splitArgs(s string, strict bool) ([]string, error) {
+	var a []string
+	var b bytes.Buffer
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isSpace(c):
+			if inWord {
+				a = append(a, b.String())
+				b.Reset()
+				inWord = false
+			}
+			continue
+		case c == '\\':
+			if i+1 == len(s) {
+				if strict {
+					return nil, &SyntaxError{s, i, "trailing backslash"}
+				}
+				b.WriteByte(c)
+				break
+			}
+			i++
+			if s[i] == '\n' {
+				continue
+			}
+			b.WriteByte(s[i])
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				if strict {
+					return nil, &SyntaxError{s, i, "unterminated single quote"}
+				}
+				end = len(s) - i - 1
+			}
+			b.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+		case c == '"':
+			start := i
+			for i++; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) >= 0 {
+					i++
+					if s[i] == '\n' {
+						continue
+					}
+				}
+				b.WriteByte(s[i])
+			}
+			if i == len(s) && strict {
+				return nil, &SyntaxError{s, start, "unterminated double quote"}
 			}
+		default:
+			b.WriteByte(c)
 		}
-		if inString%2 == 0 && isSpace(s[i]) {
-			if start == i { //escape continuous space
-				start += lenSep
+		inWord = true
+	}
+	if inWord {
+		a = append(a, b.String())
+	}
+	return a, nil
+}
+
+//SplitWindowsArgs splits a command-line text as CommandLineToArgvW of Windows does.
+//Words are separated by spaces and tabs; double quotes group blanks into a word,
+//and "" in quotes is a literal double quote; 2n backslashes followed by a
+//double quote become n backslashes, and 2n+1 ones become n backslashes and a
+//literal double quote; other backslashes are literal.
+//The first word is taken as the others but not the program name rule.
+func SplitWindowsArgs(s string) []string {
+	var a []string
+	var b bytes.Buffer
+	inWord, inQuote := false, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case (c == ' ' || c == '\t') && !inQuote:
+			if inWord {
+				a = append(a, b.String())
+				b.Reset()
+				inWord = false
+			}
+			continue
+		case c == '\\':
+			n := 0
+			for ; i < len(s) && s[i] == '\\'; i++ {
+				n++
+			}
+			if i < len(s) && s[i] == '"' {
+				b.WriteString(strings.Repeat("\\", n/2))
+				if n%2 == 1 {
+					b.WriteByte('"')
+				} else {
+					i-- //let the quote be processed next
+				}
 			} else {
-				a[na] = s[start+escape : i-escape]
-				na++
-				start = i + lenSep
-				i += lenSep - 1
+				b.WriteString(strings.Repeat("\\", n))
+				i--
 			}
+		case c == '"':
+			if inQuote && i+1 < len(s) && s[i+1] == '"' {
+				b.WriteByte('"')
+				i++
+			} else {
+				inQuote = !inQuote
+			}
+		default:
+			b.WriteByte(c)
 		}
+		inWord = true
 	}
-	if start < len(s) {
-		a[na] = s[start+escape : len(s)-escape]
-	} else {
-		na--
+	if inWord {
+		a = append(a, b.String())
 	}
+	return a
+}
 
-	return a[0 : na+1]
+//JoinLine joins args into a command-line text that SplitArgs or POSIX shell
+//splits back to args. Words need quoting are single quoted.
+func JoinLine(args []string) string {
+	a := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case arg == "":
+			a[i] = "''"
+		case strings.IndexFunc(arg, needShellQuote) < 0:
+			a[i] = arg
+		default:
+			a[i] = "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+		}
+	}
+	return strings.Join(a, " ")
+}
+
+//needShellQuote reports whether r is special to POSIX shell
+func needShellQuote(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		strings.ContainsRune("-_=+,./:@%^", r) || r >= 0x80)
+}
+
+//JoinWindowsArgs joins args into a command-line text that SplitWindowsArgs or
+//CommandLineToArgvW splits back to args
+func JoinWindowsArgs(args []string) string {
+	a := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && strings.IndexAny(arg, " \t\"") < 0 {
+			a[i] = arg
+			continue
+		}
+		var b bytes.Buffer
+		b.WriteByte('"')
+		n := 0 //count of pending backslashes
+		for j := 0; j < len(arg); j++ {
+			switch arg[j] {
+			case '\\':
+				n++
+				continue
+			case '"':
+				b.WriteString(strings.Repeat("\\", 2*n+1))
+			default:
+				b.WriteString(strings.Repeat("\\", n))
+			}
+			n = 0
+			b.WriteByte(arg[j])
+		}
+		b.WriteString(strings.Repeat("\\", 2*n))
+		b.WriteByte('"')
+		a[i] = b.String()
+	}
+	return strings.Join(a, " ")
 }
 
 //FormatLineHead ensure all lines of s are lead with linehead string
@@ -64,3 +228,71 @@ func FormatLineHead(s, lineHead string) (r string) {
 	r = lineHeadExpr.ReplaceAllString(s, lineHead)
 	return
 }
+
+//WrapText word-wraps every line of s that is wider than width.
+//Continuation lines keep the leading blanks of the line they come from, so
+//a wrapped paragraph hangs under its first word. Lines that fit are kept as is.
+func WrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if textWidth(line) > width {
+			lines[i] = wrapLine(line, width)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func wrapLine(line string, width int) string {
+	body := strings.TrimLeft(line, " \t")
+	lead := line[:len(line)-len(body)]
+	leadWidth := textWidth(lead)
+
+	var b bytes.Buffer
+	cur := 0
+	for _, word := range strings.Fields(body) {
+		w := textWidth(word)
+		switch {
+		case cur == 0:
+		case cur+1+w > width:
+			b.WriteByte('\n')
+			cur = 0
+		default:
+			b.WriteByte(' ')
+			cur++
+		}
+		if cur == 0 {
+			b.WriteString(lead)
+			cur = leadWidth
+		}
+		b.WriteString(word)
+		cur += w
+	}
+	return b.String()
+}
+
+//textWidth returns the count of terminal columns s occupies,
+//east asian wide characters occupy two columns
+func textWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n++
+		if isWideRune(r) {
+			n++
+		}
+	}
+	return n
+}
+
+func isWideRune(r rune) bool {
+	return r >= 0x1100 && (r <= 0x115F || // Hangul Jamo
+		(r >= 0x2E80 && r <= 0xA4CF && r != 0x303F) || // CJK ... Yi
+		(r >= 0xAC00 && r <= 0xD7A3) || // Hangul Syllables
+		(r >= 0xF900 && r <= 0xFAFF) || // CJK Compatibility Ideographs
+		(r >= 0xFE30 && r <= 0xFE4F) || // CJK Compatibility Forms
+		(r >= 0xFF00 && r <= 0xFF60) || // Fullwidth Forms
+		(r >= 0xFFE0 && r <= 0xFFE6) ||
+		(r >= 0x20000 && r <= 0x3FFFD))
+}
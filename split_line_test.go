@@ -7,6 +7,7 @@ package cmdline_test
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/vipally/cmdline"
@@ -20,8 +21,8 @@ func TestSplitLine(t *testing.T) {
 		"127.0.0.1",
 		"-n=",
 		"2",
-		`" --x = 5 "`,
-		`"--help"`,
+		" --x = 5 ",
+		"--help",
 		"a",
 	}
 	suc := true
@@ -40,3 +41,88 @@ func TestSplitLine(t *testing.T) {
 	//fmt.Println(len(cmd), len(result))
 	t.Error("SplitLine fail")
 }
+
+func TestSplitLineQuotes(t *testing.T) {
+	for _, c := range []struct {
+		line string
+		want []string
+	}{
+		{`a "b c"d`, []string{"a", "b cd"}},
+		{`'it''s'`, []string{"its"}},
+		{`'it'\''s' "x\"y"`, []string{"it's", `x"y`}},
+		{"a\nb\r\nc", []string{"a", "b", "c"}},
+		{`x "unbalanced y`, []string{"x", "unbalanced y"}},
+		{`x 'unbalanced y`, []string{"x", "unbalanced y"}},
+		{`x\`, []string{`x\`}},
+		{"a b c d e f g", []string{"a", "b", "c", "d", "e", "f", "g"}},
+		{"  ", nil},
+	} {
+		if got := cmdline.SplitLine(c.line); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SplitLine(%q) = %q, want %q", c.line, got, c.want)
+		}
+	}
+	_, err := cmdline.SplitArgs(`x "unbalanced y`)
+	if e, ok := err.(*cmdline.SyntaxError); !ok || e.Pos != 2 || e.Msg != "unterminated double quote" {
+		t.Errorf("unbalanced quote error = %v, want unterminated double quote at position 2", err)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	for _, c := range []struct {
+		line string
+		want []string
+		pos  int //position of syntax error, -1 means no error
+	}{
+		{`a "b c"d`, []string{"a", "b cd"}, -1},
+		{`'it''s'`, []string{"its"}, -1},
+		{`'it'\''s'`, []string{"it's"}, -1},
+		{`a\ b "x\"y\z" '\n' ""`, []string{"a b", `x"y\z`, `\n`, ""}, -1},
+		{"a\\\nb", []string{"ab"}, -1},
+		{`a "b`, nil, 2},
+		{`a 'b`, nil, 2},
+		{`a\`, nil, 1},
+	} {
+		got, err := cmdline.SplitArgs(c.line)
+		if c.pos >= 0 {
+			if e, ok := err.(*cmdline.SyntaxError); !ok || e.Pos != c.pos {
+				t.Errorf("SplitArgs(%q) error = %v, want position %d", c.line, err, c.pos)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SplitArgs(%q) = %q, %v, want %q", c.line, got, err, c.want)
+		}
+	}
+}
+
+func TestSplitWindowsArgs(t *testing.T) {
+	for _, c := range []struct {
+		line string
+		want []string
+	}{
+		{`"a b c" d e`, []string{"a b c", "d", "e"}},
+		{`"ab\"c" "\\" d`, []string{`ab"c`, `\`, "d"}},
+		{`a\\\b d"e f"g h`, []string{`a\\\b`, "de fg", "h"}},
+		{`a\\\"b c d`, []string{`a\"b`, "c", "d"}},
+		{`a\\\\"b c" d e`, []string{`a\\b c`, "d", "e"}},
+		{`"say ""hi"""`, []string{`say "hi"`}},
+	} {
+		if got := cmdline.SplitWindowsArgs(c.line); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SplitWindowsArgs(%q) = %q, want %q", c.line, got, c.want)
+		}
+	}
+}
+
+func TestJoinLine(t *testing.T) {
+	args := []string{"ping", "-n=4", "", "a b", "it's", `C:\dir\`, `"q"`, "$HOME", "中文"}
+	line := cmdline.JoinLine(args)
+	if want := `ping -n=4 '' 'a b' 'it'\''s' 'C:\dir\' '"q"' '$HOME' 中文`; line != want {
+		t.Errorf("JoinLine() = %s, want %s", line, want)
+	}
+	if got, err := cmdline.SplitArgs(line); err != nil || !reflect.DeepEqual(got, args) {
+		t.Errorf("SplitArgs(JoinLine()) = %q, %v, want %q", got, err, args)
+	}
+	if got := cmdline.SplitWindowsArgs(cmdline.JoinWindowsArgs(args)); !reflect.DeepEqual(got, args) {
+		t.Errorf("SplitWindowsArgs(JoinWindowsArgs()) = %q, want %q", got, args)
+	}
+}
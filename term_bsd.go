@@ -0,0 +1,10 @@
+// +build darwin freebsd netbsd openbsd dragonfly
+
+package cmdline
+
+import "syscall"
+
+const (
+	ioctlGetTermios = syscall.TIOCGETA
+	ioctlSetTermios = syscall.TIOCSETA
+)
@@ -0,0 +1,10 @@
+// +build linux
+
+package cmdline
+
+import "syscall"
+
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
@@ -0,0 +1,30 @@
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly,!windows
+
+package cmdline
+
+import "errors"
+
+//terminalWidth is not supported on this platform
+func terminalWidth(fd uintptr) int {
+	return 0
+}
+
+//isTerminal is not supported on this platform
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+//setEcho is not supported on this platform
+func setEcho(fd uintptr, on bool) error {
+	return errors.New("terminal echo control is not supported")
+}
+
+//makeRaw is not supported on this platform
+func makeRaw(fd uintptr) (restore func(), err error) {
+	return nil, errors.New("terminal raw mode is not supported")
+}
+
+//enableColor is not needed on this platform
+func enableColor(fd uintptr) bool {
+	return true
+}
@@ -0,0 +1,69 @@
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package cmdline
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+type winSize struct {
+	row, col       uint16
+	xpixel, ypixel uint16
+}
+
+func ioctl(fd, req uintptr, arg unsafe.Pointer) error {
+	if _, _, e := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg)); e != 0 {
+		return e
+	}
+	return nil
+}
+
+//terminalWidth returns the column count of terminal fd, or 0 if fd is not a terminal
+func terminalWidth(fd uintptr) int {
+	var ws winSize
+	if ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)) != nil {
+		return 0
+	}
+	return int(ws.col)
+}
+
+//isTerminal reports whether fd refers to a terminal
+func isTerminal(fd uintptr) bool {
+	var t syscall.Termios
+	return ioctl(fd, ioctlGetTermios, unsafe.Pointer(&t)) == nil
+}
+
+//setEcho turns on or off echo of terminal fd
+func setEcho(fd uintptr, on bool) error {
+	var t syscall.Termios
+	if err := ioctl(fd, ioctlGetTermios, unsafe.Pointer(&t)); err != nil {
+		return err
+	}
+	if on {
+		t.Lflag |= syscall.ECHO
+	} else {
+		t.Lflag &^= syscall.ECHO
+	}
+	return ioctl(fd, ioctlSetTermios, unsafe.Pointer(&t))
+}
+
+//makeRaw let terminal fd pass every key without echo, returns the function to restore it
+func makeRaw(fd uintptr) (restore func(), err error) {
+	var old syscall.Termios
+	if err := ioctl(fd, ioctlGetTermios, unsafe.Pointer(&old)); err != nil {
+		return nil, err
+	}
+	t := old
+	t.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ISIG
+	t.Cc[syscall.VMIN], t.Cc[syscall.VTIME] = 1, 0
+	if err := ioctl(fd, ioctlSetTermios, unsafe.Pointer(&t)); err != nil {
+		return nil, err
+	}
+	return func() { ioctl(fd, ioctlSetTermios, unsafe.Pointer(&old)) }, nil
+}
+
+//enableColor reports whether terminal fd shows ANSI escape sequences, which all terminals do
+func enableColor(fd uintptr) bool {
+	return true
+}
@@ -0,0 +1,100 @@
+// +build windows
+
+package cmdline
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+	procGetConsoleMode             = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode             = kernel32.NewProc("SetConsoleMode")
+)
+
+const (
+	enableProcessedInput            = 0x0001 //input mode
+	enableLineInput                 = 0x0002 //input mode
+	enableEchoInput                 = 0x0004 //input mode
+	enableVirtualTerminalInput      = 0x0200 //input mode
+	enableVirtualTerminalProcessing = 0x0004 //output mode
+)
+
+type coord struct {
+	x, y int16
+}
+
+type smallRect struct {
+	left, top, right, bottom int16
+}
+
+type consoleScreenBufferInfo struct {
+	size              coord
+	cursorPosition    coord
+	attributes        uint16
+	window            smallRect
+	maximumWindowSize coord
+}
+
+//terminalWidth returns the column count of console fd, or 0 if fd is not a console
+func terminalWidth(fd uintptr) int {
+	var info consoleScreenBufferInfo
+	r, _, _ := procGetConsoleScreenBufferInfo.Call(fd, uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return 0
+	}
+	return int(info.window.right-info.window.left) + 1
+}
+
+//isTerminal reports whether fd refers to a console
+func isTerminal(fd uintptr) bool {
+	var mode uint32
+	r, _, _ := procGetConsoleMode.Call(fd, uintptr(unsafe.Pointer(&mode)))
+	return r != 0
+}
+
+//setEcho turns on or off echo of console fd
+func setEcho(fd uintptr, on bool) error {
+	var mode uint32
+	if r, _, err := procGetConsoleMode.Call(fd, uintptr(unsafe.Pointer(&mode))); r == 0 {
+		return err
+	}
+	if on {
+		mode |= enableEchoInput
+	} else {
+		mode &^= enableEchoInput
+	}
+	if r, _, err := procSetConsoleMode.Call(fd, uintptr(mode)); r == 0 {
+		return err
+	}
+	return nil
+}
+
+//makeRaw let console fd pass every key without echo, returns the function to restore it
+func makeRaw(fd uintptr) (restore func(), err error) {
+	var mode uint32
+	if r, _, err := procGetConsoleMode.Call(fd, uintptr(unsafe.Pointer(&mode))); r == 0 {
+		return nil, err
+	}
+	raw := mode&^(enableProcessedInput|enableLineInput|enableEchoInput) | enableVirtualTerminalInput
+	if r, _, err := procSetConsoleMode.Call(fd, uintptr(raw)); r == 0 {
+		return nil, err
+	}
+	return func() { procSetConsoleMode.Call(fd, uintptr(mode)) }, nil
+}
+
+//enableColor turns on ANSI escape sequences of console fd,
+//it returns false if the console is too old to support them
+func enableColor(fd uintptr) bool {
+	var mode uint32
+	if r, _, _ := procGetConsoleMode.Call(fd, uintptr(unsafe.Pointer(&mode))); r == 0 {
+		return false
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return true
+	}
+	r, _, _ := procSetConsoleMode.Call(fd, uintptr(mode|enableVirtualTerminalProcessing))
+	return r != 0
+}
@@ -0,0 +1,156 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//TimeLayouts are the layouts TimeVar tries by order, the first one formats values
+var TimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+//ParseTime parses s as one of:
+//    now, today, yesterday, tomorrow
+//    relative to now, as "-1h", "+30m", "-7d"
+//    any of layouts, in time zone loc if s has no one
+//    Unix epoch seconds, as "1500000000" or "1500000000.5"
+//Layouts are tried before epoch seconds, so numeric layouts as "20060102" work.
+//nil loc means time.Local.
+func ParseTime(s string, layouts []string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	switch s = strings.TrimSpace(s); strings.ToLower(s) {
+	case "now":
+		return now, nil
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		if strings.HasSuffix(s, "d") {
+			if days, err := strconv.Atoi(s[:len(s)-1]); err == nil {
+				return now.AddDate(0, 0, days), nil
+			}
+		}
+		if d, err := time.ParseDuration(s); err == nil {
+			return now.Add(d), nil
+		}
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	if t, ok := parseEpoch(s); ok {
+		return t.In(loc), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as time", s)
+}
+
+//parseEpoch parses s as Unix epoch seconds with optional fraction, as "1500000000.5"
+func parseEpoch(s string) (time.Time, bool) {
+	parts := strings.SplitN(s, ".", 2)
+	if parts[0] == "" || strings.Trim(parts[0], "0123456789") != "" {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	var nsec int64
+	if len(parts) == 2 {
+		frac := parts[1]
+		if frac == "" || len(frac) > 9 || strings.Trim(frac, "0123456789") != "" {
+			return time.Time{}, false
+		}
+		nsec, _ = strconv.ParseInt((frac + "00000000")[:9], 10, 64)
+	}
+	return time.Unix(sec, nsec), true
+}
+
+type timeValue struct {
+	p       *time.Time
+	layouts []string
+	loc     *time.Location
+}
+
+func newTimeValue(val time.Time, p *time.Time, layouts []string, loc *time.Location) *timeValue {
+	*p = val
+	return &timeValue{p: p, layouts: layouts, loc: loc}
+}
+
+func (t *timeValue) Set(s string) error {
+	v, err := ParseTime(s, t.layouts, t.loc)
+	if err != nil {
+		return err
+	}
+	*t.p = v
+	return nil
+}
+
+func (t *timeValue) Get() interface{} { return *t.p }
+
+//reset sets the zero time for empty def, which Set can not parse
+func (t *timeValue) reset(def string) {
+	if def == "" {
+		*t.p = time.Time{}
+		return
+	}
+	t.Set(def)
+}
+
+func (t *timeValue) target() interface{} { return t.p }
+
+func (t *timeValue) String() string {
+	if t.p == nil || t.p.IsZero() {
+		return ""
+	}
+	layout := time.RFC3339Nano
+	if len(t.layouts) > 0 {
+		layout = t.layouts[0]
+	}
+	return t.p.Format(layout)
+}
+
+//TimeVar defines a time.Time flag with specified name, default value, and usage string.
+//The argument p points to a time.Time variable in which to store the value of the flag.
+//Values are parsed by ParseTime with TimeLayouts in local time zone.
+func (f *FlagSet) TimeVar(p *time.Time, name string, logic_name string, value time.Time, required bool, usage string) {
+	f.Var(newTimeValue(value, p, TimeLayouts, nil), name, logic_name, required, usage)
+}
+
+//TimeVar defines a time.Time command line flag with specified name, default value, and usage string.
+//Values are parsed by ParseTime with TimeLayouts in local time zone.
+func TimeVar(p *time.Time, name string, logic_name string, value time.Time, required bool, usage string) {
+	CommandLine.TimeVar(p, name, logic_name, value, required, usage)
+}
+
+//TimeLayoutVar defines a time.Time flag parsed by ParseTime with layouts in time zone loc,
+//the first layout formats the value. nil loc means time.Local.
+func (f *FlagSet) TimeLayoutVar(p *time.Time, name string, logic_name string, value time.Time, layouts []string, loc *time.Location, required bool, usage string) {
+	f.Var(newTimeValue(value, p, layouts, loc), name, logic_name, required, usage)
+}
+
+//TimeLayoutVar defines a time.Time command line flag parsed by ParseTime with layouts
+//in time zone loc, the first layout formats the value. nil loc means time.Local.
+func TimeLayoutVar(p *time.Time, name string, logic_name string, value time.Time, layouts []string, loc *time.Location, required bool, usage string) {
+	CommandLine.TimeLayoutVar(p, name, logic_name, value, layouts, loc, required, usage)
+}
@@ -0,0 +1,101 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestParseTime(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	for _, c := range []struct {
+		s    string
+		need time.Time
+	}{
+		{"2017-03-04T05:06:07Z", time.Date(2017, 3, 4, 5, 6, 7, 0, time.UTC)},
+		{"2017-03-04T05:06:07.5+02:00", time.Date(2017, 3, 4, 3, 6, 7, 5e8, time.UTC)},
+		{"2017-03-04 05:06", time.Date(2017, 3, 4, 5, 6, 0, 0, loc)},
+		{"2017-03-04", time.Date(2017, 3, 4, 0, 0, 0, 0, loc)},
+		{"1500000000", time.Unix(1500000000, 0)},
+		{"1500000000.25", time.Unix(1500000000, 25e7)},
+	} {
+		got, err := cmdline.ParseTime(c.s, cmdline.TimeLayouts, loc)
+		if err != nil || !got.Equal(c.need) {
+			t.Errorf("ParseTime(%q) = %v, %v, need %v", c.s, got, err, c.need)
+		}
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	for _, c := range []struct {
+		s    string
+		need time.Time
+	}{
+		{"now", now},
+		{"-1h", now.Add(-time.Hour)},
+		{"+30m", now.Add(30 * time.Minute)},
+		{"-7d", now.AddDate(0, 0, -7)},
+	} {
+		got, err := cmdline.ParseTime(c.s, nil, loc)
+		if d := got.Sub(c.need); err != nil || d < 0 || d > time.Minute {
+			t.Errorf("ParseTime(%q) = %v, %v, need about %v", c.s, got, err, c.need)
+		}
+	}
+	numeric := []string{"20060102"}
+	if got, err := cmdline.ParseTime("20240131", numeric, loc); err != nil || !got.Equal(time.Date(2024, 1, 31, 0, 0, 0, 0, loc)) {
+		t.Errorf("ParseTime(20240131) by numeric layout = %v, %v", got, err)
+	}
+	if got, err := cmdline.ParseTime("1500000000", numeric, loc); err != nil || !got.Equal(time.Unix(1500000000, 0)) {
+		t.Errorf("ParseTime(1500000000) by numeric layout = %v, %v, need epoch", got, err)
+	}
+	if got, _ := cmdline.ParseTime("yesterday", nil, loc); !got.Equal(today.AddDate(0, 0, -1)) {
+		t.Errorf("ParseTime(yesterday) = %v", got)
+	}
+	for _, s := range []string{"", "2017-13-01", "1h", "15000.", "-x"} {
+		if _, err := cmdline.ParseTime(s, cmdline.TimeLayouts, loc); err == nil {
+			t.Errorf("ParseTime(%q) need error", s)
+		}
+	}
+}
+
+func TestTimeVar(t *testing.T) {
+	cmd := cmdline.NewFlagSet("time", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.UsageWidth(80)
+	var since, until time.Time
+	cmd.TimeVar(&since, "since", "since", time.Time{}, false, "show logs since `time`")
+	cmd.TimeLayoutVar(&until, "until", "until", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC),
+		[]string{"2006/01/02"}, time.UTC, false, "show logs until")
+	cmd.TimeVar(&since, "s", "since", since, false, "synonym of -since")
+	if cmd.Lookup("s") != cmd.Lookup("since") {
+		t.Error("-s and -since of the same variable are not synonyms")
+	}
+	if err := cmd.Parse([]string{"-since=2017-01-01T00:00:00Z", "-until=2017/02/03"}); err != nil {
+		t.Fatal(err)
+	}
+	if !since.Equal(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)) || !until.Equal(time.Date(2017, 2, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("since=%v until=%v", since, until)
+	}
+	usage := cmd.GetUsage()
+	for _, need := range []string{
+		"  -since|s=<since>  time\n",
+		"  -until=<until>    time  (default 2017/01/02)\n",
+	} {
+		if !strings.Contains(usage, need) {
+			t.Errorf("usage need %q, got:\n%s", need, usage)
+		}
+	}
+
+	cmd.Reset()
+	if !since.IsZero() || !until.Equal(time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("after Reset since=%v until=%v", since, until)
+	}
+}
@@ -0,0 +1,125 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+)
+
+//Validator checks a new value of a flag, as the String() of its Value
+type Validator func(value string) error
+
+//hookError is an error returned by validators or OnSet callbacks
+type hookError struct {
+	err error
+}
+
+func (e *hookError) Error() string {
+	return e.err.Error()
+}
+
+//runHooks checks value changed from old by validators and OnSet callbacks of flag
+func (f *Flag) runHooks(old, value string) error {
+	for _, v := range f.validators {
+		if err := v(value); err != nil {
+			return &hookError{err}
+		}
+	}
+	if old == value {
+		return nil
+	}
+	for _, fn := range f.onSet {
+		if err := fn(old, value); err != nil {
+			return &hookError{err}
+		}
+	}
+	return nil
+}
+
+//setFailed reports err of setting value to flag by failf.
+//Errors of validators and OnSet callbacks are reported with show name of flag,
+//others by format with a and err. The result unwraps to err, or the error of hooks.
+func (f *FlagSet) setFailed(flag *Flag, value string, err error, format string, a ...interface{}) error {
+	if e, ok := err.(*hookError); ok {
+		return &valueError{f.failf(f.msg(MsgRejectedValue), flag.mask(value), flag.GetShowName(), flag.LogicName, e.err), e.err}
+	}
+	return &valueError{f.failf(format, append(a, err)...), err}
+}
+
+//valueError is an error of setting a flag formatted by message catalog,
+//it unwraps to the error of Value, so errors.As finds *RangeError or *SyntaxError in it
+type valueError struct {
+	msg   error //formatted message
+	cause error //error of Value or hooks
+}
+
+func (e *valueError) Error() string {
+	return e.msg.Error()
+}
+
+func (e *valueError) Unwrap() error {
+	return e.cause
+}
+
+//OnSet registers fn to be called when the value of command line flag name changes
+func OnSet(name string, fn func(old, new string) error) {
+	CommandLine.OnSet(name, fn)
+}
+
+//OnSet registers fn to be called when the value of flag name changes by Set or Parse.
+//A non-nil error of fn rejects the new value, and the old one is restored.
+func (f *FlagSet) OnSet(name string, fn func(old, new string) error) {
+	flag := f.mustLookup("OnSet", name)
+	flag.onSet = append(flag.onSet, fn)
+}
+
+//Validate adds validators to command line flag name
+func Validate(name string, validators ...Validator) {
+	CommandLine.Validate(name, validators...)
+}
+
+//Validate adds validators to flag name, they check every new value by Set or Parse.
+//The first error rejects the new value, and the old one is restored.
+func (f *FlagSet) Validate(name string, validators ...Validator) {
+	flag := f.mustLookup("Validate", name)
+	flag.validators = append(flag.validators, validators...)
+}
+
+//IntRange returns a Validator that requires an integer in [min, max]
+func IntRange(min, max int64) Validator {
+	return func(value string) error {
+		n, err := strconv.ParseInt(value, 0, 64)
+		if err != nil {
+			return err
+		}
+		if n < min || n > max {
+			return &RangeError{Value: value, Min: strconv.FormatInt(min, 10), Max: strconv.FormatInt(max, 10)}
+		}
+		return nil
+	}
+}
+
+//MatchRegexp returns a Validator that requires a value matching regular expression expr
+func MatchRegexp(expr string) Validator {
+	re := regexp.MustCompile(expr)
+	return func(value string) error {
+		if !re.MatchString(value) {
+			return fmt.Errorf("%q does not match %s", value, expr)
+		}
+		return nil
+	}
+}
+
+//FileExists returns a Validator that requires the path of an existing file or directory
+func FileExists() Validator {
+	return func(value string) error {
+		_, err := os.Stat(value)
+		return err
+	}
+}
@@ -0,0 +1,112 @@
+// CopyRight 2016 @Ally Dale. All rights reserved.
+// Author  : Ally Dale(vipally@gmail.com)
+// Blog    : http://blog.csdn.net/vipally
+// Site    : https://github.com/vipally
+
+package cmdline_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vipally/cmdline"
+)
+
+func TestValidate(t *testing.T) {
+	var out bytes.Buffer
+	cmd := cmdline.NewFlagSet("validate", cmdline.ContinueOnError)
+	cmd.SetOutput(&out)
+	ttl := cmd.Int("t", "ttl", 64, false, "TTL")
+	cmd.Alias("ttl", "t")
+	cmd.Validate("ttl", cmdline.IntRange(1, 255))
+	name := cmd.String("name", "name", "a", false, "name")
+	cmd.Validate("name", cmdline.MatchRegexp(`^[a-z]+$`))
+	file := cmd.String("f", "file", os.DevNull, false, "file")
+	cmd.Validate("f", cmdline.FileExists())
+
+	if err := cmd.Parse([]string{"-t=128", "-name=abc"}); err != nil {
+		t.Fatal(err)
+	}
+	if *ttl != 128 || *name != "abc" {
+		t.Errorf("ttl=%d name=%q", *ttl, *name)
+	}
+	err := cmd.Parse([]string{"-ttl=300"})
+	if need := `invalid value "300" for flag -t|ttl=<ttl>: 300 is out of range [1, 255]`; err == nil || err.Error() != need {
+		t.Errorf("Parse error = %v, need %s", err, need)
+	}
+	if !strings.Contains(out.String(), "-t|ttl=<ttl>") {
+		t.Errorf("error is not reported to Output: %q", out.String())
+	}
+	if *ttl != 128 {
+		t.Errorf("rejected value is not rolled back, ttl=%d", *ttl)
+	}
+	if err := cmd.Set("name", "ABC"); err == nil || *name != "abc" {
+		t.Errorf("Set(name, ABC) = %v, name=%q", err, *name)
+	}
+	if err := cmd.Set("file", "/no/such/file"); err == nil || *file != os.DevNull {
+		t.Errorf("Set(file) = %v, file=%q", err, *file)
+	}
+}
+
+func TestOnSet(t *testing.T) {
+	cmd := cmdline.NewFlagSet("onset", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	mode := cmd.String("m", "mode", "fast", false, "mode")
+	var changes []string
+	cmd.OnSet("m", func(old, new string) error {
+		if new == "locked" {
+			return errors.New("mode is locked")
+		}
+		changes = append(changes, old+"->"+new)
+		return nil
+	})
+	for _, v := range []string{"slow", "slow", "locked", "fast"} {
+		cmd.Set("m", v)
+	}
+	if got, need := strings.Join(changes, ","), "fast->slow,slow->fast"; got != need {
+		t.Errorf("changes = %s, need %s", got, need)
+	}
+	if err := cmd.Set("m", "locked"); err == nil || *mode != "fast" {
+		t.Errorf("Set(m, locked) = %v, mode=%q", err, *mode)
+	}
+}
+
+func TestHooksGetFlags(t *testing.T) {
+	cmd := cmdline.NewFlagSet("hooks", cmdline.ContinueOnError)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.Concurrent(true)
+	cmd.Int("max", "max", 10, false, "max")
+	n := cmd.Int("n", "count", 1, false, "count")
+	cmd.Validate("n", func(value string) error {
+		if value > cmd.GetString("max") {
+			return errors.New("count is over max")
+		}
+		return nil
+	})
+	cmd.OnSet("n", func(old, new string) error {
+		if new == "0" {
+			cmd.Set("max", "5")
+		}
+		return nil
+	})
+
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+		if err := cmd.Set("n", "0"); err != nil || cmd.GetInt("max") != 5 {
+			t.Errorf("Set(n, 0) = %v, max=%d", err, cmd.GetInt("max"))
+		}
+		if err := cmd.Set("n", "7"); err == nil || *n != 0 {
+			t.Errorf("Set(n, 7) = %v, n=%d", err, *n)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("hooks getting or setting flags deadlock")
+	}
+}